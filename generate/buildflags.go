@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// forwardedFlags are the flags of the go command changing which files and
+// packages are loaded, which are forwarded to packages.Load.
+var forwardedFlags = []string{"tags", "mod", "modfile"}
+
+// valueFlags are the flags of the go command taking a value, which may be
+// passed as the next argument.
+var valueFlags = map[string]bool{
+	"C": true, "asmflags": true, "bench": true, "benchtime": true,
+	"blockprofile": true, "blockprofilerate": true, "buildmode": true,
+	"compiler": true, "count": true, "covermode": true, "coverpkg": true,
+	"coverprofile": true, "cpu": true, "cpuprofile": true, "exec": true,
+	"fuzz": true, "fuzzminimizetime": true, "fuzztime": true,
+	"gccgoflags": true, "gcflags": true, "installsuffix": true, "ldflags": true,
+	"list": true, "memprofile": true, "memprofilerate": true, "mod": true,
+	"modfile": true, "mutexprofile": true, "mutexprofilefraction": true,
+	"o": true, "outputdir": true, "overlay": true, "p": true, "parallel": true,
+	"pgo": true, "pkgdir": true, "run": true, "shuffle": true, "skip": true,
+	"tags": true, "timeout": true, "toolexec": true, "trace": true, "vet": true,
+}
+
+// ConfigureBuild mirrors the flags of the go command in args, the arguments
+// after the command, and GOFLAGS, GOOS and GOARCH of the environment with env
+// appended into the package loading of the generator, so the files and
+// packages injected are those the go command builds. Files excluded by the
+// resulting build constraints aren't injected.
+func (g *Generator) ConfigureBuild(args []string, env []string) {
+	environ := append(os.Environ(), env...)
+	flags := parseGoFlags(strings.Fields(getenv(environ, "GOFLAGS")))
+	for name, value := range parseGoFlags(args) {
+		flags[name] = value
+	}
+
+	g.Config.BuildFlags = nil
+	for _, name := range forwardedFlags {
+		if value, ok := flags[name]; ok {
+			g.Config.BuildFlags = append(g.Config.BuildFlags, "-"+name+"="+value)
+		}
+	}
+	if len(env) > 0 {
+		g.Config.Env = environ
+	}
+
+	ctx := build.Default
+	if goos := getenv(environ, "GOOS"); goos != "" {
+		ctx.GOOS = goos
+	}
+	if goarch := getenv(environ, "GOARCH"); goarch != "" {
+		ctx.GOARCH = goarch
+	}
+	switch getenv(environ, "CGO_ENABLED") {
+	case "0":
+		ctx.CgoEnabled = false
+	case "1":
+		ctx.CgoEnabled = true
+	default:
+		// The go command disables cgo when cross compiling.
+		if ctx.GOOS != runtime.GOOS || ctx.GOARCH != runtime.GOARCH {
+			ctx.CgoEnabled = false
+		}
+	}
+	// Tags are comma separated, or space separated by older go commands.
+	ctx.BuildTags = strings.FieldsFunc(flags["tags"], func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	g.buildContext = &ctx
+}
+
+// context returns the build context files are matched with.
+func (g *Generator) context() *build.Context {
+	if g.buildContext == nil {
+		return &build.Default
+	}
+	return g.buildContext
+}
+
+// matchFile reports whether the file is built with the active build
+// constraints.
+func (g *Generator) matchFile(path string) bool {
+	match, err := g.context().MatchFile(filepath.Dir(path), filepath.Base(path))
+	return err == nil && match
+}
+
+// parseGoFlags returns the values of the forwarded flags in args. Parsing
+// stops at the first argument that isn't a flag, like the go command does.
+func parseGoFlags(args []string) map[string]string {
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if eq := strings.Index(name, "="); eq >= 0 {
+			name, value, hasValue = name[:eq], name[eq+1:], true
+		}
+		if !hasValue && valueFlags[name] && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		if contains(forwardedFlags, name) {
+			flags[name] = value
+		}
+	}
+	return flags
+}
+
+// getenv returns the last value of the variable key in environ.
+func getenv(environ []string, key string) string {
+	value := ""
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, key+"=") {
+			value = kv[len(key)+1:]
+		}
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package generate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseGoFlags(t *testing.T) {
+	cases := []struct {
+		args     []string
+		expected map[string]string
+	}{
+		{[]string{"-tags", "a,b", "-mod=vendor", "."}, map[string]string{"tags": "a,b", "mod": "vendor"}},
+		{[]string{"-o", "bin", "--modfile=alt.mod", "-v", "./..."}, map[string]string{"modfile": "alt.mod"}},
+		// Flags after the packages are arguments of the program.
+		{[]string{".", "-tags", "a"}, map[string]string{}},
+		// The values of go test flags passed as the next argument aren't
+		// packages.
+		{[]string{"-vet", "off", "-fuzztime", "10s", "-memprofilerate", "1", "-tags", "a", "./..."}, map[string]string{"tags": "a"}},
+	}
+	for _, c := range cases {
+		if out := parseGoFlags(c.args); !reflect.DeepEqual(c.expected, out) {
+			t.Errorf("parseGoFlags(%q) = %#v, expected %#v", c.args, out, c.expected)
+		}
+	}
+}
+
+func TestConfigureBuild(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-buildflags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"tagged.go":    "// +build debug\n\npackage main\n",
+		"x_windows.go": "package main\n",
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := NewGenerator(false)
+	g.ConfigureBuild([]string{"-tags=debug", "-mod=mod", "."}, []string{"GOOS=linux", "GOFLAGS=-mod=vendor -tags=other"})
+	if expected := []string{"-tags=debug", "-mod=mod"}; !reflect.DeepEqual(expected, g.Config.BuildFlags) {
+		t.Errorf("Expected %q got %q.", expected, g.Config.BuildFlags)
+	}
+	if !g.matchFile(filepath.Join(dir, "tagged.go")) {
+		t.Error("Expected tagged.go to match with -tags=debug")
+	}
+	if g.matchFile(filepath.Join(dir, "x_windows.go")) {
+		t.Error("Expected x_windows.go not to match with GOOS=linux")
+	}
+
+	g.ConfigureBuild(nil, []string{"GOOS=windows", "GOFLAGS=-tags=other"})
+	if expected := []string{"-tags=other"}; !reflect.DeepEqual(expected, g.Config.BuildFlags) {
+		t.Errorf("Expected %q got %q.", expected, g.Config.BuildFlags)
+	}
+	if g.matchFile(filepath.Join(dir, "tagged.go")) || !g.matchFile(filepath.Join(dir, "x_windows.go")) {
+		t.Error("Expected only x_windows.go to match with GOOS=windows")
+	}
+}
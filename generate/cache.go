@@ -0,0 +1,238 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/build"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/d4l3k/go-pry/pry"
+	"golang.org/x/tools/go/packages"
+)
+
+// exportTable is the symbol table of an imported package. The tables of
+// packages with a fixed version are cached in the cache directory.
+type exportTable struct {
+	Name    string
+	Symbols []pry.Symbol
+}
+
+// cacheFormat is the version of the format of the cached export tables. It's
+// part of the cache keys, so changing the format invalidates the entries
+// written by older versions.
+const cacheFormat = 2
+
+// DefaultCacheDir returns the directory export tables are cached in, or "" if
+// the user has no cache directory.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "go-pry", "exports")
+}
+
+// CleanCache removes the cached export tables and plugins.
+func (g *Generator) CleanCache() error {
+	for _, dir := range []string{g.CacheDir, g.PluginCacheDir} {
+		if dir == "" {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exports returns the export tables of the import paths, as imported from
+// dir. Cached tables are used for packages of the standard library and of
+// modules with a version, the others are type checked.
+func (g *Generator) exports(paths []string, dir string) (map[string]*exportTable, error) {
+	tables := map[string]*exportTable{}
+	keys := map[string]string{}
+	if g.CacheDir != "" {
+		for path, version := range g.versions(paths, dir) {
+			if version == "" {
+				continue
+			}
+			key := g.cacheKey(path, version)
+			keys[path] = key
+			if table, ok := g.readCache(key); ok {
+				tables[path] = table
+			}
+		}
+	}
+	for _, path := range paths {
+		if tables[path] != nil {
+			continue
+		}
+		pkg, err := g.loadTypes(path, dir)
+		if err != nil {
+			return nil, err
+		}
+		table := &exportTable{
+			Name:    pkg.Name(),
+			Symbols: pry.Symbols(pkg, types.Object.Exported),
+		}
+		tables[path] = table
+		if key := keys[path]; key != "" {
+			if err := g.writeCache(key, table); err != nil {
+				g.Debug("Failed to cache the exports of %s: %s\n", path, err)
+			}
+		}
+	}
+	return tables, nil
+}
+
+// versions returns the versions of the packages with the import paths, as
+// imported from dir. Packages of the standard library have the version "std"
+// and are recognized without loading them. The modules of the others are
+// loaded once per directory, since they're the same for every file.
+func (g *Generator) versions(paths []string, dir string) map[string]string {
+	versions := map[string]string{}
+	var load []string
+	for _, path := range paths {
+		if g.isStdPackage(path) {
+			versions[path] = "std"
+		} else if version, ok := g.modules[dir+"\x00"+path]; ok {
+			versions[path] = version
+		} else {
+			load = append(load, path)
+		}
+	}
+	if len(load) == 0 {
+		return versions
+	}
+
+	config := g.Config
+	config.Mode = packages.NeedName | packages.NeedModule
+	config.Dir = dir
+	pkgs, err := loadPackages(&config, load...)
+	if err != nil {
+		g.Debug("Not using the export cache: %s\n", err)
+		return versions
+	}
+	if g.modules == nil {
+		g.modules = map[string]string{}
+	}
+	for _, pkg := range pkgs {
+		version := moduleVersion(pkg)
+		g.modules[dir+"\x00"+pkg.PkgPath] = version
+		versions[pkg.PkgPath] = version
+	}
+	return versions
+}
+
+// moduleVersion returns the version of the module providing pkg, or "" if
+// it can change without its version changing, like the packages of the main
+// module or of modules replaced by a directory.
+func moduleVersion(pkg *packages.Package) string {
+	switch mod := pkg.Module; {
+	case mod == nil:
+		return ""
+	case mod.Replace != nil:
+		if mod.Replace.Version == "" {
+			return ""
+		}
+		return mod.Replace.Path + "@" + mod.Replace.Version
+	case mod.Main || mod.Version == "":
+		return ""
+	default:
+		return mod.Path + "@" + mod.Version
+	}
+}
+
+// cacheKey returns the key the export table of the package with the import
+// path and version is cached under. The build constraints are part of the
+// key, since the exports can depend on them, and so is the cache format.
+func (g *Generator) cacheKey(path, version string) string {
+	ctx := g.context()
+	constraints := ctx.GOOS + "/" + ctx.GOARCH + " " + strings.Join(ctx.BuildTags, ",") + " " + strconv.FormatBool(ctx.CgoEnabled)
+	sum := sha256.Sum256([]byte(strconv.Itoa(cacheFormat) + "\x00" + path + "\x00" + version + "\x00" + g.goVersion() + "\x00" + constraints))
+	return hex.EncodeToString(sum[:])
+}
+
+// isStdPackage reports whether the import path is a package of the standard
+// library of the go command.
+func (g *Generator) isStdPackage(path string) bool {
+	if !isStd(path) {
+		return false
+	}
+	g.goEnv()
+	info, err := os.Stat(filepath.Join(g.goRootCache, "src", filepath.FromSlash(path)))
+	return err == nil && info.IsDir()
+}
+
+// goVersion returns the version of the go command, whose standard library the
+// programs are built with.
+func (g *Generator) goVersion() string {
+	g.goEnv()
+	return g.goVersionCache
+}
+
+// goEnv looks up the version and GOROOT of the go command once.
+func (g *Generator) goEnv() {
+	if g.goVersionCache != "" {
+		return
+	}
+	g.goVersionCache, g.goRootCache = runtime.Version(), build.Default.GOROOT
+	out, err := exec.Command("go", "env", "GOVERSION", "GOROOT").Output()
+	if env := strings.Split(strings.TrimSpace(string(out)), "\n"); err == nil && len(env) == 2 && env[0] != "" {
+		g.goVersionCache, g.goRootCache = env[0], env[1]
+	}
+}
+
+// readCache returns the cached export table with the key.
+func (g *Generator) readCache(key string) (*exportTable, bool) {
+	buf, err := ioutil.ReadFile(filepath.Join(g.CacheDir, key+".json"))
+	if err != nil {
+		return nil, false
+	}
+	var table exportTable
+	if err := json.Unmarshal(buf, &table); err != nil {
+		g.Debug("Ignoring the corrupted cache entry %s: %s\n", key, err)
+		return nil, false
+	}
+	return &table, true
+}
+
+// writeCache caches the export table under the key. It's written to a temp
+// file first, so concurrent runs never read a partial entry.
+func (g *Generator) writeCache(key string, table *exportTable) error {
+	if err := os.MkdirAll(g.CacheDir, 0755); err != nil {
+		return err
+	}
+	buf, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(g.CacheDir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(g.CacheDir, key+".json"))
+}
+
+// isStd reports whether the import path is in the standard library, whose
+// paths have no dot in their first element.
+func isStd(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
@@ -0,0 +1,121 @@
+package generate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestExportCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	plugins, err := ioutil.TempDir("", "go-pry-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(plugins)
+
+	g := NewGenerator(false)
+	g.CacheDir = dir
+	g.PluginCacheDir = plugins
+	paths := []string{"strings", "github.com/d4l3k/go-pry/pry"}
+	tables, err := g.exports(paths, ".")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if tables["strings"].Name != "strings" || len(tables["strings"].Symbols) == 0 {
+		t.Fatalf("Expected the exports of strings, got %#v", tables["strings"])
+	}
+
+	// Only the standard library package is cached, the main module can
+	// change without its version changing.
+	entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected one cache entry, got %v", entries)
+	}
+	buf, err := json.Marshal(exportTable{Name: "cached"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(entries[0], buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tables, err = g.exports(paths, ".")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if tables["strings"].Name != "cached" {
+		t.Errorf("Expected the cached table, got %#v", tables["strings"])
+	}
+	if tables["github.com/d4l3k/go-pry/pry"].Name != "pry" {
+		t.Errorf("Expected the exports of pry, got %#v", tables["github.com/d4l3k/go-pry/pry"])
+	}
+
+	if err := g.CleanCache(); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists(dir) {
+		t.Error("Cache dir not removed")
+	}
+	if fileExists(plugins) {
+		t.Error("Plugin cache dir not removed")
+	}
+}
+
+func TestExportCacheSkipsLoading(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	paths := []string{"strings", "github.com/pkg/errors"}
+	g := NewGenerator(false)
+	g.CacheDir = dir
+	if _, err := g.exports(paths, "."); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	load := loadPackages
+	defer func() { loadPackages = load }()
+	var loaded []string
+	loadPackages = func(config *packages.Config, patterns ...string) ([]*packages.Package, error) {
+		loaded = append(loaded, patterns...)
+		return load(config, patterns...)
+	}
+
+	// The next run only loads the module of the dependency to find its
+	// version, the standard library package isn't loaded at all.
+	g = NewGenerator(false)
+	g.CacheDir = dir
+	tables, err := g.exports(paths, ".")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if tables["strings"].Name != "strings" || tables["github.com/pkg/errors"].Name != "errors" {
+		t.Fatalf("Expected the cached tables, got %#v", tables)
+	}
+	if expected := []string{"github.com/pkg/errors"}; !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("Expected to load %v, got %v", expected, loaded)
+	}
+
+	// Other files importing the packages don't load them again.
+	loaded = nil
+	if _, err := g.exports(paths, "."); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(loaded) > 0 {
+		t.Errorf("Expected no loads, got %v", loaded)
+	}
+}
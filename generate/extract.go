@@ -0,0 +1,33 @@
+package generate
+
+import (
+	"go/types"
+
+	"github.com/d4l3k/go-pry/pry"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// Extract loads the packages matching the patterns and returns the source of
+// a file in package pkgName that registers their exported symbols with
+// pry.RegisterPackage.
+func (g *Generator) Extract(pkgName string, patterns []string) ([]byte, error) {
+	config := g.Config
+	config.Mode = packages.NeedName | packages.NeedTypes
+	pkgs, err := loadPackages(&config, patterns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "load")
+	}
+	var typesPkgs []*types.Package
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, errors.Errorf("%s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+		if pkg.IllTyped {
+			return nil, errors.Errorf("%s: incomplete type information", pkg.PkgPath)
+		}
+		g.Debug("Extracting %s\n", pkg.PkgPath)
+		typesPkgs = append(typesPkgs, pkg.Types)
+	}
+	return pry.SymbolTable(pkgName, typesPkgs)
+}
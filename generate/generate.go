@@ -2,16 +2,21 @@ package generate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/build"
+	"go/importer"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/d4l3k/go-pry/pry"
@@ -19,18 +24,48 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+// loadPackages loads the packages the generator needs. Tests replace it to
+// count the loads.
+var loadPackages = packages.Load
+
 type Generator struct {
-	contexts []pryContext
-	debug    bool
-	Config   packages.Config
+	debug  bool
+	Config packages.Config
+	// CacheDir is the directory export tables are cached in. Caching is
+	// disabled if it's empty.
+	CacheDir string
+	// PluginCacheDir is the directory pry caches the plugins of imported
+	// packages in, which is removed with the export tables.
+	PluginCacheDir string
+	// goVersionCache and goRootCache are the version and GOROOT of the go
+	// command, once they're known.
+	goVersionCache string
+	goRootCache    string
+	// modules are the module versions of the imports loaded for the export
+	// cache, by directory and import path.
+	modules map[string]string
+	// buildContext matches files with the build constraints set by
+	// ConfigureBuild.
+	buildContext *build.Context
+
+	// tables are the symbol tables of the packages with pry statements, by
+	// directory and package name.
+	tables map[string]*packageTable
+
+	// overlay maps the files OverlayPry injected into to their injected
+	// copies in tempDir.
+	overlay map[string]string
+	tempDir string
 }
 
 func NewGenerator(debug bool) *Generator {
 	return &Generator{
 		debug: debug,
 		Config: packages.Config{
-			Mode: packages.NeedName | packages.NeedSyntax,
+			Mode: packages.NeedName | packages.NeedTypes,
 		},
+		CacheDir:       DefaultCacheDir(),
+		PluginCacheDir: pry.PluginCacheDir(),
 	}
 }
 
@@ -41,13 +76,23 @@ func (g Generator) Debug(templ string, k ...interface{}) {
 	}
 }
 
-// ExecuteGoCmd runs the 'go' command with certain parameters.
+// ExecuteGoCmd runs the 'go' command with certain parameters. Commands
+// building packages are passed the files injected by OverlayPry with
+// -overlay.
 func (g *Generator) ExecuteGoCmd(ctx context.Context, args []string, env []string) error {
 	binary, err := exec.LookPath("go")
 	if err != nil {
 		return err
 	}
 
+	if len(args) > 0 && len(g.overlay) > 0 && buildCommands[args[0]] {
+		overlay, err := g.writeOverlay()
+		if err != nil {
+			return err
+		}
+		args = append([]string{args[0], "-overlay=" + overlay}, g.withTables(args[1:])...)
+	}
+
 	cmd := exec.CommandContext(ctx, binary, args...)
 	cmd.Env = append(os.Environ(), env...)
 	cmd.Stdout = os.Stdout
@@ -56,15 +101,206 @@ func (g *Generator) ExecuteGoCmd(ctx context.Context, args []string, env []strin
 	return cmd.Run()
 }
 
-// InjectPry walks the scope and replaces pry.Pry with pry.Apply(pry.Scope{...}).
+// buildCommands are the go commands accepting the -overlay flag.
+var buildCommands = map[string]bool{
+	"build":   true,
+	"install": true,
+	"list":    true,
+	"run":     true,
+	"test":    true,
+	"vet":     true,
+}
+
+// writeOverlay writes the -overlay file replacing the files injected by
+// OverlayPry and adding the symbol tables of their packages, and returns its
+// path.
+func (g *Generator) writeOverlay() (string, error) {
+	for _, t := range g.tables {
+		src, err := t.source()
+		if err != nil {
+			return "", err
+		}
+		copyPath := filepath.Join(g.tempDir, fmt.Sprintf("%d-%s", len(g.overlay), t.fileName()))
+		if err := ioutil.WriteFile(copyPath, src, 0644); err != nil {
+			return "", err
+		}
+		g.overlay[t.path()] = copyPath
+	}
+	overlay := struct {
+		Replace map[string]string
+	}{g.overlay}
+	buf, err := json.Marshal(overlay)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(g.tempDir, "overlay.json")
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// withTables adds the symbol tables to the arguments of a go command building
+// files listed on the command line, which only builds those files.
+func (g *Generator) withTables(args []string) []string {
+	start := 0
+	for start < len(args) && !strings.HasSuffix(args[start], ".go") {
+		start++
+	}
+	end := start
+	for end < len(args) && strings.HasSuffix(args[end], ".go") {
+		end++
+	}
+	if start == end {
+		return args
+	}
+	var tables []string
+	for _, t := range g.tables {
+		for _, file := range args[start:end] {
+			if abs, err := filepath.Abs(file); err == nil && filepath.Dir(abs) == t.dir {
+				tables = append(tables, t.path())
+				break
+			}
+		}
+	}
+	sort.Strings(tables)
+	out := append(append(append([]string{}, args[:end]...), tables...), args[end:]...)
+	return out
+}
+
+// OverlayPry writes a copy of the file with pry.Pry replaced by
+// pry.Apply(pry.Capture(...)) into a temp directory and returns its path, or
+// "" if the file has no pry statements. The file itself isn't modified;
+// ExecuteGoCmd builds the copy in its place, together with the symbol table
+// of its package. Cleanup removes the copies.
+func (g *Generator) OverlayPry(filePath string) (string, error) {
+	filePath, src, _, err := g.inject(filePath)
+	if err != nil || src == "" {
+		return "", err
+	}
+	if g.tempDir == "" {
+		if g.tempDir, err = ioutil.TempDir("", "go-pry-overlay"); err != nil {
+			return "", err
+		}
+		g.overlay = map[string]string{}
+	}
+	copyPath := filepath.Join(g.tempDir, fmt.Sprintf("%d-%s", len(g.overlay), filepath.Base(filePath)))
+	if err := ioutil.WriteFile(copyPath, []byte(src), 0644); err != nil {
+		return "", err
+	}
+	g.overlay[filePath] = copyPath
+	return copyPath, nil
+}
+
+// Cleanup removes the copies written by OverlayPry.
+func (g *Generator) Cleanup() error {
+	if g.tempDir == "" {
+		return nil
+	}
+	err := os.RemoveAll(g.tempDir)
+	g.tempDir, g.overlay, g.tables = "", nil, nil
+	return err
+}
+
+// InjectPry replaces pry.Pry with pry.Apply(pry.Capture(...)) in the file
+// itself, keeping the original as .file.gopry until RevertPry, and writes the
+// symbol table of its package next to it. It returns the path of the file, or
+// "" if it has no pry statements. Use OverlayPry to leave the file untouched.
+//
+// The injection is recorded in the journal of the module before the file is
+// modified, so it can be reverted even if go-pry is killed.
 func (g *Generator) InjectPry(filePath string) (string, error) {
+	filePath, src, table, err := g.inject(filePath)
+	if err != nil || src == "" {
+		return "", err
+	}
+	original, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	newPath := filepath.Dir(filePath) + "/." + filepath.Base(filePath) + "pry"
+
+	j, err := openJournal(filepath.Dir(filePath))
+	if err != nil {
+		return "", err
+	}
+	if e, ok := j.lookup(filePath); ok {
+		if state, err := e.state(); err != nil {
+			return "", err
+		} else if state != Reverted {
+			return "", errors.Errorf("%s is already injected (%s), run go-pry revert first", filePath, state)
+		}
+	}
+	j.add(journalEntry{
+		File:     filePath,
+		Backup:   newPath,
+		Original: hash(original),
+		Injected: hash([]byte(src)),
+	})
+	if err := j.save(); err != nil {
+		return "", err
+	}
+
+	if err := replaceFile(filePath, newPath, src); err != nil {
+		return "", err
+	}
+	if err := writeTable(table); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// writeTable writes the symbol table file of a package injected in place. It's
+// journaled as a generated file, so reverting removes it.
+func writeTable(t *packageTable) error {
+	src, err := t.source()
+	if err != nil {
+		return err
+	}
+	path := t.path()
+	j, err := openJournal(t.dir)
+	if err != nil {
+		return err
+	}
+	if e, ok := j.lookup(path); ok {
+		if state, err := e.state(); err != nil {
+			return err
+		} else if state == Edited {
+			return errors.Errorf("%s was edited after it was generated, run go-pry revert first", path)
+		}
+	} else if _, err := os.Stat(path); err == nil {
+		return errors.Errorf("%s already exists", path)
+	}
+	j.add(journalEntry{File: path, Injected: hash(src)})
+	if err := j.save(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, src, 0644)
+}
+
+// replaceFile moves the file to backup and writes src in its place.
+func replaceFile(filePath, backup, src string) error {
+	if err := os.Rename(filePath, backup); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, []byte(src), 0644)
+}
+
+// inject walks the scope of the file and returns its absolute path, its
+// source with pry.Pry replaced by pry.Apply(pry.Capture(...)) and the symbol
+// table of its package, with the imports of the file added. The source is
+// empty if the file has no pry statements.
+func (g *Generator) inject(filePath string) (string, string, *packageTable, error) {
 	g.Debug("Prying into %s\n", filePath)
 	filePath, err := filepath.Abs(filePath)
 	if err != nil {
-		return "", nil
+		return "", "", nil, nil
+	}
+	if !g.matchFile(filePath) {
+		g.Debug("Skipping %s, excluded by build constraints\n", filePath)
+		return "", "", nil, nil
 	}
-
-	g.contexts = make([]pryContext, 0)
 
 	fset := token.NewFileSet() // positions are relative to fset
 
@@ -72,188 +308,138 @@ func (g *Generator) InjectPry(filePath string) (string, error) {
 	// but stop after processing the imports.
 	f, err := parser.ParseFile(fset, filePath, nil, 0)
 	if err != nil {
-		return "", err
+		return "", "", nil, err
 	}
 
-	g.Config.Dir = filepath.Dir(filePath)
+	contexts, pkg := g.pryContexts(fset, f, filePath)
+	if len(contexts) == 0 {
+		return "", "", nil, nil
+	}
+	table := g.table(filepath.Dir(filePath), fset, pkg)
 
-	packagePairs := []string{}
+	var specs []*ast.ImportSpec
+	var missing []string
 	for _, imp := range f.Imports {
-		importStr := imp.Path.Value[1 : len(imp.Path.Value)-1]
-		if importStr != "../pry" {
-			pkgs, err := packages.Load(&g.Config, importStr)
-			if err != nil {
-				return "", err
-			}
-			pkg := pkgs[0]
-			importName := pkg.Name
-			if imp.Name != nil {
-				importName = imp.Name.Name
-			}
-			pair := "\"" + importName + "\": pry.Package{Name: \"" + pkg.Name + "\", Functions: map[string]interface{}{"
-			added := make(map[string]bool)
-			exports, err := g.GetExports(importName, pkg.Syntax, added)
-			if err != nil {
-				return "", err
-			}
-			pair += exports
-			pair += "}}, "
-			packagePairs = append(packagePairs, pair)
+		importStr, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return "", "", nil, err
 		}
-	}
-
-	var funcs []*ast.FuncDecl
-	var vars []string
-
-	// Print the imports from the file's AST.
-	for k, v := range f.Scope.Objects {
-		switch decl := v.Decl.(type) {
-		case *ast.FuncDecl:
-			funcs = append(funcs, decl)
-		case *ast.ValueSpec:
-			vars = append(vars, k)
+		if importStr == "../pry" || importStr == "C" {
+			continue
 		}
-	}
-
-	for _, f := range funcs {
-		vars = append(vars, f.Name.Name)
-		if f.Recv != nil {
-			vars = g.extractFields(vars, f.Recv.List)
+		if imp.Name != nil && (imp.Name.Name == "_" || imp.Name.Name == ".") {
+			continue
 		}
-		if f.Type != nil {
-			if f.Type.Params != nil {
-				vars = g.extractFields(vars, f.Type.Params.List)
-			}
-			if f.Type.Results != nil {
-				vars = g.extractFields(vars, f.Type.Results.List)
-			}
+		specs = append(specs, imp)
+		if table.imports[importStr] == nil && !contains(missing, importStr) {
+			missing = append(missing, importStr)
+		}
+	}
+	exports, err := g.exports(missing, table.dir)
+	if err != nil {
+		return "", "", nil, err
+	}
+	for path, exportTable := range exports {
+		table.imports[path] = exportTable
+	}
+	imports := map[string]string{}
+	for _, imp := range specs {
+		importStr, _ := strconv.Unquote(imp.Path.Value)
+		importName := table.imports[importStr].Name
+		if imp.Name != nil {
+			importName = imp.Name.Name
 		}
-		vars = g.extractVariables(vars, f.Body.List)
+		imports[importName] = importStr
 	}
+	importNames := make([]string, 0, len(imports))
+	for name := range imports {
+		importNames = append(importNames, name)
+	}
+	sort.Strings(importNames)
+	importMap := "map[string]string{"
+	for _, name := range importNames {
+		importMap += fmt.Sprintf("%q: %q, ", name, imports[name])
+	}
+	importMap += "}"
 
 	fileTextBytes, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		return "", nil
+		return "", "", nil, nil
 	}
 
 	fileText := (string)(fileTextBytes)
 
 	offset := 0
 
-	if len(g.contexts) == 0 {
-		return "", nil
-	}
+	g.Debug(" :: Found %d pry statements.\n", len(contexts))
 
-	g.Debug(" :: Found %d pry statements.\n", len(g.contexts))
-
-	for _, context := range g.contexts {
-		filteredVars := filterVars(context.Vars)
-		obj := "&pry.Scope{Vals:map[string]interface{}{ "
-		for _, v := range filteredVars {
-			obj += "\"" + v + "\": " + v + ", "
+	for _, context := range contexts {
+		vals := "map[string]interface{}{"
+		for _, v := range context.Vars {
+			vals += "\"" + v.Name + "\": " + v.expr() + ", "
 		}
-		obj += strings.Join(packagePairs, "")
-		obj += "}}"
-		text := "pry.Apply(" + obj + ")"
+		vals += "}"
+		text := fmt.Sprintf("pry.Apply(pry.Capture(%q, %s, %s))", table.key, importMap, vals)
 		fileText = fileText[0:context.Start+offset] + text + fileText[context.End+offset:]
-		offset = len(text) - (context.End - context.Start)
+		offset += len(text) - (context.End - context.Start)
 	}
 
-	newPath := filepath.Dir(filePath) + "/." + filepath.Base(filePath) + "pry"
+	return filePath, fileText, table, nil
+}
 
-	err = os.Rename(filePath, newPath)
+// loadTypes returns the type checked package with the import path, as
+// imported from dir.
+func (g *Generator) loadTypes(path, dir string) (*types.Package, error) {
+	config := g.Config
+	config.Mode = packages.NeedName | packages.NeedTypes
+	config.Dir = dir
+	pkgs, err := loadPackages(&config, path)
 	if err != nil {
-		return "", err
+		return nil, errors.Wrapf(err, "loading %q", path)
 	}
-	ioutil.WriteFile(filePath, ([]byte)(fileText), 0644)
-	return filePath, nil
+	if len(pkgs) != 1 {
+		return nil, errors.Errorf("loading %q: got %d packages", path, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, errors.Wrapf(pkg.Errors[0], "loading %q", path)
+	}
+	if pkg.IllTyped {
+		// The export data of the go command isn't always readable by
+		// go/packages, in which case the package is type checked from
+		// source.
+		g.Debug("No type information for %s, type checking it from source\n", path)
+		imp := importer.ForCompiler(token.NewFileSet(), "source", nil).(types.ImporterFrom)
+		return imp.ImportFrom(path, dir, 0)
+	}
+	return pkg.Types, nil
 }
 
-// GetExports returns a string of gocode that represents the exports (constants/functions) of an ast.Package.
-func (g *Generator) GetExports(importName string, files []*ast.File, added map[string]bool) (string, error) {
-	vars := ""
-	for _, file := range files {
-		// Print the imports from the file's AST.
-		scope := pry.NewScope()
-		for k, obj := range file.Scope.Objects {
-			if added[k] {
-				continue
-			}
-			added[k] = true
-			firstLetter := k[0:1]
-			if firstLetter == strings.ToUpper(firstLetter) && firstLetter != "_" {
-
-				isType := false
-
-				switch stmt := obj.Decl.(type) {
-				/*
-					case *ast.ValueSpec:
-						if len(stmt.Values) > 0 {
-							out, err := pry.InterpretExpr(scope, stmt.Values[0])
-							if err != nil {
-								fmt.Println("ERR", err)
-								//continue
-							} else {
-								scope[obj.Name] = out
-							}
-						}
-				*/
-				case *ast.TypeSpec:
-					switch typ := stmt.Type.(type) {
-					case *ast.StructType:
-						isType = true
-						scope.Set(obj.Name, typ)
-
-					default:
-						out, err := scope.Interpret(stmt.Type)
-						if err != nil {
-							g.Debug("TypeSpec ERR %s\n", err.Error())
-							//continue
-						} else {
-							scope.Set(obj.Name, out)
-							isType = true
-						}
-					}
-				}
-
-				if obj.Kind != ast.Typ || isType {
-					path := importName + "." + k
-					vars += "\"" + k + "\": "
-					if isType {
-						out, _ := scope.Get(obj.Name)
-						switch v := out.(type) {
-						case reflect.Type:
-							zero := reflect.Zero(v).Interface()
-							val := fmt.Sprintf("%#v", zero)
-							if zero == nil {
-								val = "nil"
-							}
-							vars += fmt.Sprintf("pry.Type(%s(%s))", path, val)
-						case *ast.StructType:
-							vars += fmt.Sprintf("pry.Type(%s{})", path)
-						default:
-							log.Fatalf("got unknown type: %T %+v", out, out)
-						}
-
-						// TODO Fix hack for very large constants
-					} else if path == "math.MaxUint64" || path == "math.MaxUint" || path == "crc64.ISO" || path == "crc64.ECMA" {
-						vars += fmt.Sprintf("uint64(%s)", path)
-					} else {
-						vars += path
-					}
-					vars += ","
-					if g.debug {
-						vars += "\n"
-					}
-				}
-			}
-		}
+// GenerateFile generates a injected file. It's injected in place, for builds
+// that can't be passed an overlay, with the original kept as .file.gopry.
+// Since the file is generated, it isn't journaled.
+func (g *Generator) GenerateFile(imports []string, extraStatements, path string) error {
+	if err := writeMain(imports, extraStatements, path); err != nil {
+		return err
+	}
+
+	path, src, table, err := g.inject(path)
+	if err != nil {
+		return err
+	}
+	// The file is built on its own, so the table is registered by the file
+	// itself, after the code of the original so the lines don't change.
+	names := map[string]string{}
+	for importPath, exportTable := range table.imports {
+		names[importPath] = exportTable.Name
 	}
-	return vars, nil
+	src += "\nfunc init() {\n" + table.init("pry", names) + "}\n"
+	return replaceFile(path, filepath.Dir(path)+"/."+filepath.Base(path)+"pry", src)
 }
 
-// GenerateFile generates a injected file.
-func (g *Generator) GenerateFile(imports []string, extraStatements, path string) error {
+// writeMain writes a main package importing imports and running
+// extraStatements before pry.Pry to path.
+func writeMain(imports []string, extraStatements, path string) error {
 	file := "package main\nimport (\n\t\"github.com/d4l3k/go-pry/pry\"\n\n"
 	for _, imp := range imports {
 		if len(imp) == 0 {
@@ -263,12 +449,7 @@ func (g *Generator) GenerateFile(imports []string, extraStatements, path string)
 	}
 	file += ")\nfunc main() {\n\t" + extraStatements + "\n\tpry.Pry()\n}\n"
 
-	if err := ioutil.WriteFile(path, []byte(file), 0644); err != nil {
-		return err
-	}
-
-	_, err := g.InjectPry(path)
-	return err
+	return ioutil.WriteFile(path, []byte(file), 0644)
 }
 
 // GenerateAndExecuteFile generates and executes a temp file with the given imports
@@ -284,9 +465,13 @@ func (g *Generator) GenerateAndExecuteFile(ctx context.Context, imports []string
 	}()
 	newPath := dir + "/main.go"
 
-	if err := g.GenerateFile(imports, extraStatements, newPath); err != nil {
+	if err := writeMain(imports, extraStatements, newPath); err != nil {
+		return err
+	}
+	if _, err := g.OverlayPry(newPath); err != nil {
 		return err
 	}
+	defer g.Cleanup()
 
 	if err := g.ExecuteGoCmd(ctx, []string{"run", newPath}, nil); err != nil {
 		return err
@@ -294,163 +479,76 @@ func (g *Generator) GenerateAndExecuteFile(ctx context.Context, imports []string
 	return nil
 }
 
-// RevertPry reverts the changes made by InjectPry.
+// RevertPry reverts the changes made by InjectPry to the files. Files that
+// are already reverted are skipped, and files edited since they were injected
+// are reported and left alone, while the other files are still reverted. The
+// symbol tables of a directory are removed with its last injected file.
 func (g *Generator) RevertPry(modifiedFiles []string) error {
 	fmt.Println("Reverting files")
+	byDir := map[string][]string{}
 	for _, file := range modifiedFiles {
-		newPath := filepath.Dir(file) + "/." + filepath.Base(file) + "pry"
-		if _, err := os.Stat(newPath); os.IsNotExist(err) {
-			return errors.Errorf("no such file or directory: %s", newPath)
-		}
-
-		err := os.Remove(file)
+		file, err := filepath.Abs(file)
 		if err != nil {
 			return err
 		}
-		err = os.Rename(newPath, file)
+		dir := filepath.Dir(file)
+		byDir[dir] = append(byDir[dir], file)
+	}
+	var failed []string
+	for dir, files := range byDir {
+		j, err := openJournal(dir)
 		if err != nil {
 			return err
 		}
+		if err := j.revert(files); err != nil {
+			failed = append(failed, err.Error())
+			continue
+		}
+		if !j.injected(dir) {
+			tables := []string{filepath.Join(dir, tableFile), filepath.Join(dir, tableTestFile)}
+			if err := j.revert(tables); err != nil {
+				failed = append(failed, err.Error())
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "\n"))
 	}
 	return nil
 }
 
-func filterVars(vars []string) (fVars []string) {
-	for _, v := range vars {
-		if v != "_" {
-			fVars = append(fVars, v)
-		}
+// RevertAll reverts every file injected in place in the module containing
+// dir.
+func (g *Generator) RevertAll(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
 	}
-	return
-}
-
-func (g *Generator) extractVariables(vars []string, l []ast.Stmt) []string {
-	for _, s := range l {
-		vars = g.handleStatement(vars, s)
+	j, err := openJournal(dir)
+	if err != nil {
+		return err
 	}
-	return vars
+	return j.revert(nil)
 }
 
-func (g *Generator) extractFields(vars []string, l []*ast.Field) []string {
-	for _, s := range l {
-		vars = g.handleIdents(vars, s.Names)
+// Status returns the files journaled as injected in place in the module
+// containing dir, with their current state.
+func (g *Generator) Status(dir string) ([]Injection, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
 	}
-	return vars
-}
-
-func (g *Generator) handleStatement(vars []string, s ast.Stmt) []string {
-	switch stmt := s.(type) {
-	case *ast.ExprStmt:
-		vars = g.handleExpr(vars, stmt.X)
-	case *ast.AssignStmt:
-		lhsStatements := (*stmt).Lhs
-		for _, v := range lhsStatements {
-			vars = g.handleExpr(vars, v)
-		}
-	case *ast.GoStmt:
-		g.handleExpr(vars, stmt.Call)
-	case *ast.IfStmt:
-		g.handleIfStmt(vars, stmt)
-	case *ast.DeclStmt:
-		decl := stmt.Decl.(*ast.GenDecl)
-		if decl.Tok == token.VAR {
-			for _, spec := range decl.Specs {
-				valSpec := spec.(*ast.ValueSpec)
-				vars = g.handleIdents(vars, valSpec.Names)
-			}
-		}
-	case *ast.BlockStmt:
-		vars = g.handleBlockStmt(vars, stmt)
-	case *ast.RangeStmt:
-		g.handleRangeStmt(vars, stmt)
-	case *ast.ForStmt:
-		vars = g.handleForStmt(vars, stmt)
-	default:
-		g.Debug("Unknown %T\n", stmt)
-	}
-	return vars
-}
-
-func (g *Generator) handleIfStmt(vars []string, stmt *ast.IfStmt) []string {
-	vars = g.handleStatement(vars, stmt.Init)
-	vars = g.handleStatement(vars, stmt.Body)
-	return vars
-}
-
-func (g *Generator) handleRangeStmt(vars []string, stmt *ast.RangeStmt) []string {
-	vars = g.handleExpr(vars, stmt.Key)
-	vars = g.handleExpr(vars, stmt.Value)
-	vars = g.handleStatement(vars, stmt.Body)
-	return vars
-}
-
-func (g *Generator) handleForStmt(vars []string, stmt *ast.ForStmt) []string {
-	vars = g.handleStatement(vars, stmt.Init)
-	vars = g.handleStatement(vars, stmt.Body)
-	return vars
-}
-
-func (g *Generator) handleBlockStmt(vars []string, stmt *ast.BlockStmt) []string {
-	vars = g.extractVariables(vars, stmt.List)
-	return vars
-}
-
-func (g *Generator) handleIdents(vars []string, idents []*ast.Ident) []string {
-	for _, i := range idents {
-		vars = append(vars, i.Name)
+	j, err := openJournal(dir)
+	if err != nil {
+		return nil, err
 	}
-	return vars
-}
-
-func (g *Generator) handleExpr(vars []string, v ast.Expr) []string {
-	switch expr := v.(type) {
-	case *ast.Ident:
-		varMap := make(map[string]bool)
-		for _, v := range vars {
-			varMap[v] = true
-		}
-		if !varMap[expr.Name] {
-			vars = append(vars, expr.Name)
-		}
-	case *ast.CallExpr:
-		switch fun := expr.Fun.(type) {
-		case *ast.SelectorExpr:
-			funcName := fun.Sel.Name
-			if funcName == "Pry" || funcName == "Apply" {
-				g.contexts = append(g.contexts, pryContext{(int)(expr.Pos() - 1), (int)(expr.End() - 1), vars})
-			}
-			//handleExpr(vars, fun.X)
-		case *ast.FuncLit:
-			g.handleExpr(vars, fun)
-		default:
-			g.Debug("Unknown function type %T\n", fun)
-		}
-		for _, arg := range expr.Args {
-			g.handleExpr(vars, arg)
-		}
-	case *ast.FuncLit:
-		if expr.Type.Params != nil {
-			for _, param := range expr.Type.Params.List {
-				for _, name := range param.Names {
-					vars = g.handleExpr(vars, name)
-				}
-			}
-		}
-		if expr.Type.Results != nil {
-			for _, param := range expr.Type.Results.List {
-				for _, name := range param.Names {
-					vars = g.handleExpr(vars, name)
-				}
-			}
+	var injections []Injection
+	for _, e := range j.Entries {
+		state, err := e.state()
+		if err != nil {
+			return nil, err
 		}
-		g.handleStatement(vars, expr.Body)
-	default:
-		g.Debug("Unknown %T\n", expr)
+		injections = append(injections, Injection{File: e.File, Backup: e.Backup, State: state})
 	}
-	return vars
-}
-
-type pryContext struct {
-	Start, End int
-	Vars       []string
+	return injections, nil
 }
@@ -1,9 +1,18 @@
 package generate
 
 import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/d4l3k/go-pry/pry"
 )
 
 func TestImportPry(t *testing.T) {
@@ -41,6 +50,86 @@ func TestImportPry(t *testing.T) {
 	}
 }
 
+func TestOverlayPry(t *testing.T) {
+	g := NewGenerator(false)
+	file := "../example/file/file.go"
+	before, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.OverlayPry(file)
+	if err != nil {
+		t.Fatalf("Failed to inject pry %v", err)
+	}
+	defer g.Cleanup()
+
+	injected, err := ioutil.ReadFile(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(injected), "pry.Apply(") {
+		t.Errorf("Expected the copy to be injected, got:\n%s", injected)
+	}
+	after, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Error("Source file was modified")
+	}
+	if fileExists(filepath.Join(filepath.Dir(file), ".file.gopry")) {
+		t.Error("Pry file should not exists")
+	}
+	abs, _ := filepath.Abs(file)
+	if g.overlay[abs] != res {
+		t.Errorf("Expected the overlay to replace %s with %s, got %v", abs, res, g.overlay)
+	}
+
+	tmp := g.tempDir
+	if err := g.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists(tmp) {
+		t.Error("Temp dir not removed")
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", `package p
+
+import "time"
+
+var V int
+const (
+	C    = time.Second
+	Big  = 1 << 63
+	Huge = 1 << 70
+)
+type T interface{}
+func F() {}
+func unexported() {}
+`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("example.com/p", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := pry.SymbolEntries("q.", "pry", pry.Symbols(pkg, types.Object.Exported))
+	expected := `"Big": uint64(q.Big),
+"C": q.C,
+"F": q.F,
+"T": pry.Type((*q.T)(nil)).Elem(),
+"V": &q.V,
+`
+	if out != expected {
+		t.Errorf("Expected %q got %q.", expected, out)
+	}
+}
+
 func fileExists(filePath string) bool {
 	_, err := os.Stat(filePath)
 	return !os.IsNotExist(err)
@@ -0,0 +1,12 @@
+//go:build !go1.18
+// +build !go1.18
+
+package generate
+
+import "go/types"
+
+// isGeneric reports whether obj is a generic function or type. There are none
+// before Go 1.18.
+func isGeneric(obj types.Object) bool {
+	return false
+}
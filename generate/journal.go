@@ -0,0 +1,262 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// journalName is the file in the module root recording the files injected in
+// place by InjectPry, so they can be reverted after a crash.
+const journalName = ".gopry-journal.json"
+
+// InjectionState is the state of a file injected in place.
+type InjectionState int
+
+const (
+	// Injected files still contain the injected code.
+	Injected InjectionState = iota
+	// Edited files were changed after they were injected.
+	Edited
+	// Reverted files have their original content back.
+	Reverted
+	// BackupMissing files have neither their original content nor a backup
+	// of it.
+	BackupMissing
+)
+
+func (s InjectionState) String() string {
+	switch s {
+	case Injected:
+		return "injected"
+	case Edited:
+		return "edited"
+	case Reverted:
+		return "reverted"
+	case BackupMissing:
+		return "backup missing"
+	}
+	return "unknown"
+}
+
+// Injection is a file injected in place and its state.
+type Injection struct {
+	File   string
+	Backup string
+	State  InjectionState
+}
+
+// journalEntry records a file injected in place with the hashes of its
+// original and injected content. Files generated by go-pry, like symbol
+// tables, have no backup or original.
+type journalEntry struct {
+	File     string
+	Backup   string
+	Original string
+	Injected string
+}
+
+// state compares the file and its backup with the hashes of the entry.
+func (e journalEntry) state() (InjectionState, error) {
+	sum, err := fileHash(e.File)
+	if err != nil {
+		return 0, err
+	}
+	if e.Backup == "" {
+		switch sum {
+		case "":
+			return Reverted, nil
+		case e.Injected:
+			return Injected, nil
+		}
+		return Edited, nil
+	}
+	if _, err := os.Stat(e.Backup); err == nil {
+		// A missing file was renamed to the backup, but not written yet.
+		if sum == e.Injected || sum == "" {
+			return Injected, nil
+		}
+		return Edited, nil
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+	if sum == e.Original {
+		return Reverted, nil
+	}
+	return BackupMissing, nil
+}
+
+// revert restores the original of the file if it's still injected. Reverting
+// a reverted file does nothing.
+func (e journalEntry) revert() error {
+	state, err := e.state()
+	if err != nil {
+		return err
+	}
+	switch state {
+	case Injected:
+		if err := os.Remove(e.File); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if e.Backup == "" {
+			return nil
+		}
+		return os.Rename(e.Backup, e.File)
+	case Edited:
+		if e.Backup == "" {
+			return errors.Errorf("%s was edited after it was generated", e.File)
+		}
+		return errors.Errorf("%s was edited after it was injected, the original is in %s", e.File, e.Backup)
+	case BackupMissing:
+		return errors.Errorf("%s was injected but its backup %s is missing", e.File, e.Backup)
+	}
+	return nil
+}
+
+// journal is the list of files of a module injected in place.
+type journal struct {
+	path    string
+	Entries []journalEntry
+}
+
+// openJournal reads the journal of the module containing dir. A module
+// without one has an empty journal.
+func openJournal(dir string) (*journal, error) {
+	j := &journal{path: filepath.Join(moduleRoot(dir), journalName)}
+	buf, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return j, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, j); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", j.path)
+	}
+	return j, nil
+}
+
+// save writes the journal, or removes it if it's empty. It's written to a
+// temp file first, so a crash never leaves a partial journal.
+func (j *journal) save() error {
+	if len(j.Entries) == 0 {
+		if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	buf, err := json.MarshalIndent(j, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+func (j *journal) lookup(file string) (journalEntry, bool) {
+	for _, e := range j.Entries {
+		if e.File == file {
+			return e, true
+		}
+	}
+	return journalEntry{}, false
+}
+
+func (j *journal) add(entry journalEntry) {
+	j.remove(entry.File)
+	j.Entries = append(j.Entries, entry)
+}
+
+func (j *journal) remove(file string) {
+	entries := j.Entries[:0]
+	for _, e := range j.Entries {
+		if e.File != file {
+			entries = append(entries, e)
+		}
+	}
+	j.Entries = entries
+}
+
+// injected reports whether files in dir other than symbol tables are
+// injected.
+func (j *journal) injected(dir string) bool {
+	for _, e := range j.Entries {
+		if filepath.Dir(e.File) == dir && e.Backup != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// revert reverts the entries of the files, or of every file if files is nil.
+// Entries that can't be reverted are kept and reported, the others are still
+// reverted.
+func (j *journal) revert(files []string) error {
+	var failed []string
+	for _, e := range append([]journalEntry(nil), j.Entries...) {
+		if files != nil && !contains(files, e.File) {
+			continue
+		}
+		if err := e.revert(); err != nil {
+			failed = append(failed, err.Error())
+			continue
+		}
+		j.remove(e.File)
+	}
+	if err := j.save(); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to revert %d files:\n%s", len(failed), strings.Join(failed, "\n"))
+	}
+	return nil
+}
+
+// moduleRoot returns the directory of the go.mod file of the module containing
+// dir, or dir if it isn't in a module.
+func moduleRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// fileHash returns the hex encoded SHA-256 of the file, or "" if it doesn't
+// exist.
+func fileHash(path string) (string, error) {
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return hash(buf), nil
+}
+
+func hash(buf []byte) string {
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
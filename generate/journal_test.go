@@ -0,0 +1,98 @@
+package generate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const journalTestSrc = `package main
+
+import "fmt"
+
+func main() {
+	a := 1
+	fmt.Println(a)
+	pry.Pry()
+}
+`
+
+func TestJournalRevert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/journal\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "main.go")
+	if err := ioutil.WriteFile(file, []byte(journalTestSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGenerator(false)
+	if _, err := g.InjectPry(file); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	states := func() []InjectionState {
+		injections, err := g.Status(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var states []InjectionState
+		for _, inj := range injections {
+			states = append(states, inj.State)
+		}
+		return states
+	}
+	// The file and the symbol table of its package.
+	if expected, out := []InjectionState{Injected, Injected}, states(); !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+	if _, err := g.InjectPry(file); err == nil {
+		t.Error("Expected injecting an injected file to fail")
+	}
+
+	injected, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, append(injected, "// edit\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.RevertPry([]string{file}); err == nil || !strings.Contains(err.Error(), "edited") {
+		t.Errorf("Expected an edited error, got %v", err)
+	}
+	if expected, out := []InjectionState{Edited, Injected}, states(); !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+
+	if err := ioutil.WriteFile(file, injected, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := g.RevertAll(dir); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if out := states(); len(out) != 0 {
+		t.Errorf("Expected no injections got %#v.", out)
+	}
+	out, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != journalTestSrc {
+		t.Errorf("Expected %q got %q.", journalTestSrc, out)
+	}
+	if fileExists(filepath.Join(dir, tableFile)) {
+		t.Error("Symbol table should be removed")
+	}
+	if fileExists(filepath.Join(dir, journalName)) {
+		t.Error("Journal should be removed")
+	}
+}
@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// PackageFiles returns the Go files of the packages the go command builds
+// when run with the command and args, the arguments after it. The files are
+// those matching the active build constraints, with test files, including
+// those of external _test packages, only for test. Packages outside the main
+// module aren't included, since their files can't be injected, and neither are
+// the packages of the main module the matched packages import.
+func (g *Generator) PackageFiles(command string, args []string) ([]string, error) {
+	patterns := packageArgs(command, args)
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+		if command == "apply" {
+			patterns = []string{"./..."}
+		}
+	}
+
+	config := g.Config
+	config.Mode = packages.NeedName | packages.NeedFiles | packages.NeedModule
+	config.Tests = command == "test"
+	pkgs, err := loadPackages(&config, patterns...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading %s", strings.Join(patterns, " "))
+	}
+
+	seen := map[string]bool{}
+	var files []string
+	for _, pkg := range pkgs {
+		// The go command reports the errors of the packages when building
+		// them.
+		for _, err := range pkg.Errors {
+			g.Debug("Loading %s: %s\n", pkg.PkgPath, err)
+		}
+		// The generated main package of the test binary.
+		if strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+		if mod := pkg.Module; mod != nil && !mod.Main || mod == nil && isStd(pkg.PkgPath) {
+			continue
+		}
+		for _, file := range pkg.GoFiles {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// packageArgs returns the package arguments in args, the arguments of the go
+// command after the command, leaving out flags and the arguments of the
+// program or test binary.
+func packageArgs(command string, args []string) []string {
+	var patterns []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || arg == "-args" || arg == "--args" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			name := strings.TrimLeft(arg, "-")
+			if !strings.Contains(name, "=") && valueFlags[name] {
+				i++
+			}
+			continue
+		}
+		if command == "run" {
+			// go run builds a package, or the .go files given first, and
+			// passes the rest to the program.
+			if !strings.HasSuffix(arg, ".go") {
+				return []string{arg}
+			}
+			for ; i < len(args) && strings.HasSuffix(args[i], ".go"); i++ {
+				patterns = append(patterns, args[i])
+			}
+			return patterns
+		}
+		patterns = append(patterns, arg)
+	}
+	return patterns
+}
@@ -0,0 +1,83 @@
+package generate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPackageArgs(t *testing.T) {
+	cases := []struct {
+		command  string
+		args     []string
+		expected []string
+	}{
+		{"build", []string{"-o", "bin", "./...", "./cmd/server"}, []string{"./...", "./cmd/server"}},
+		{"test", []string{"./internal/...", "-run", "TestX", "-v"}, []string{"./internal/..."}},
+		{"test", []string{".", "-args", "pkg"}, []string{"."}},
+		{"test", []string{"-vet", "off", "./..."}, []string{"./..."}},
+		{"run", []string{"-tags=a", "./cmd/server", "arg"}, []string{"./cmd/server"}},
+		{"run", []string{"main.go", "util.go", "arg.go.txt"}, []string{"main.go", "util.go"}},
+		{"build", nil, nil},
+	}
+	for _, c := range cases {
+		if out := packageArgs(c.command, c.args); !reflect.DeepEqual(c.expected, out) {
+			t.Errorf("packageArgs(%q, %q) = %q, expected %q", c.command, c.args, out, c.expected)
+		}
+	}
+}
+
+func TestPackageFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"go.mod":             "module example.com/packages\n",
+		"a/a.go":             "package a\n",
+		"a/a_test.go":        "package a\n",
+		"a/x_test.go":        "package a_test\n",
+		"a/tagged.go":        "// +build debug\n\npackage a\n",
+		"cmd/server/main.go": "package main\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		command  string
+		args     []string
+		expected []string
+	}{
+		{"build", []string{"./..."}, []string{"a/a.go", "cmd/server/main.go"}},
+		{"run", []string{"./cmd/server", "./a"}, []string{"cmd/server/main.go"}},
+		{"test", []string{"./a"}, []string{"a/a.go", "a/a_test.go", "a/x_test.go"}},
+		{"build", []string{"-tags", "debug", "./a"}, []string{"a/a.go", "a/tagged.go"}},
+		{"vet", nil, nil},
+	}
+	for _, c := range cases {
+		g := NewGenerator(false)
+		g.Config.Dir = dir
+		g.ConfigureBuild(c.args, nil)
+		out, err := g.PackageFiles(c.command, c.args)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var expected []string
+		for _, file := range c.expected {
+			expected = append(expected, filepath.Join(dir, file))
+		}
+		if !reflect.DeepEqual(expected, out) {
+			t.Errorf("%s %q: expected %q got %q", c.command, c.args, expected, out)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package generate
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type pryContext struct {
+	Start, End int
+	Vars       []pryVar
+}
+
+// pryVar is an identifier visible at a pry statement. Variables are captured
+// by reference, so assignments at the prompt change the program's variables,
+// and types as their reflect.Type.
+type pryVar struct {
+	Name string
+	Ref  bool
+	Type bool
+}
+
+// expr returns the expression capturing the identifier.
+func (v pryVar) expr() string {
+	switch {
+	case v.Ref:
+		return "&" + v.Name
+	case v.Type:
+		return "pry.Type((*" + v.Name + ")(nil)).Elem()"
+	}
+	return v.Name
+}
+
+// pryContexts returns the pry statements of the file f with the identifiers
+// visible at each of them, and the package of the file. The package is type
+// checked to get its scopes, so shadowed and not yet declared variables are
+// left out.
+func (g *Generator) pryContexts(fset *token.FileSet, f *ast.File, filePath string) ([]pryContext, *types.Package) {
+	files := append([]*ast.File{f}, g.packageFiles(fset, f, filePath)...)
+	info := &types.Info{Scopes: map[ast.Node]*types.Scope{}}
+	conf := types.Config{
+		FakeImportC: true,
+		// Only the scopes are needed, which don't depend on the imports.
+		Importer: emptyImporter{},
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(f.Name.Name, fset, files, info)
+
+	fileScope := info.Scopes[f]
+	if fileScope == nil {
+		return nil, nil
+	}
+	tokFile := fset.File(f.Pos())
+
+	var contexts []pryContext
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isPryCall(call) {
+			return true
+		}
+		contexts = append(contexts, pryContext{
+			Start: tokFile.Offset(call.Pos()),
+			End:   tokFile.Offset(call.End()),
+			Vars:  visibleVars(fset, fileScope.Innermost(call.Pos()), call.Pos()),
+		})
+		return false
+	})
+	return contexts, pkg
+}
+
+// isPryCall reports whether the call is pry.Pry, or pry.Apply of a file that
+// is already injected.
+func isPryCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == "pry" && (sel.Sel.Name == "Pry" || sel.Sel.Name == "Apply")
+}
+
+// visibleVars returns the variables, constants, functions and types visible at
+// pos in scope, sorted by name. Package level declarations are in the symbol
+// table of the package, so only those of test files, which the table can't
+// reference, are included. Generic functions and types are left out since
+// they can't be referenced without instantiating them.
+func visibleVars(fset *token.FileSet, scope *types.Scope, pos token.Pos) []pryVar {
+	var vars []pryVar
+	seen := map[string]bool{}
+	for s := scope; s != nil && s != types.Universe; s = s.Parent() {
+		for _, name := range s.Names() {
+			if seen[name] || name == "_" {
+				continue
+			}
+			seen[name] = true
+			// The innermost declaration of the name before pos, which might
+			// be in an outer scope if the inner one is declared after it.
+			_, obj := scope.LookupParent(name, pos)
+			if obj == nil || isGeneric(obj) {
+				continue
+			}
+			if obj.Parent() == obj.Pkg().Scope() && !inTestFile(fset, obj) {
+				continue
+			}
+			switch obj.(type) {
+			case *types.Var:
+				vars = append(vars, pryVar{Name: name, Ref: true})
+			case *types.Const:
+				vars = append(vars, pryVar{Name: name})
+			case *types.Func:
+				if name != "init" {
+					vars = append(vars, pryVar{Name: name})
+				}
+			case *types.TypeName:
+				vars = append(vars, pryVar{Name: name, Type: true})
+			}
+		}
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// packageFiles parses the other files of the package of f in its directory
+// that match the active build constraints. Test files are only included for test
+// files.
+func (g *Generator) packageFiles(fset *token.FileSet, f *ast.File, filePath string) []*ast.File {
+	dir := filepath.Dir(filePath)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	isTest := strings.HasSuffix(filePath, "_test.go")
+	var files []*ast.File
+	for _, info := range infos {
+		name := info.Name()
+		path := filepath.Join(dir, name)
+		if info.IsDir() || !strings.HasSuffix(name, ".go") || path == filePath {
+			continue
+		}
+		if !isTest && strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if !g.matchFile(path) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			g.Debug("Skipping %s: %s\n", path, err)
+			continue
+		}
+		if file.Name.Name == f.Name.Name {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// inTestFile reports whether obj is declared in a test file.
+func inTestFile(fset *token.FileSet, obj types.Object) bool {
+	return strings.HasSuffix(fset.Position(obj.Pos()).Filename, "_test.go")
+}
+
+// emptyImporter imports every package as an empty one.
+type emptyImporter struct{}
+
+func (emptyImporter) Import(importPath string) (*types.Package, error) {
+	pkg := types.NewPackage(importPath, path.Base(importPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
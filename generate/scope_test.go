@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const scopeTestSrc = `package main
+
+import (
+	"fmt"
+
+	"github.com/d4l3k/go-pry/pry"
+)
+
+const c = 1
+
+var global int
+
+func helper(p int) (r int) { return p }
+
+func init() {}
+
+func main() {
+	a := 1
+	switch s := a; s {
+	case 1:
+		pry.Pry()
+	}
+	var i interface{} = a
+	switch v := i.(type) {
+	case int:
+		func(x int) {
+			a := "shadowed"
+			pry.Pry()
+			_ = a
+		}(v)
+	}
+	for k := range []int{} {
+		pry.Pry()
+		after := k
+		_ = after
+	}
+	fmt.Println(a, c, global)
+}
+`
+
+func TestPryContexts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-scope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "main.go")
+	if err := ioutil.WriteFile(file, []byte(scopeTestSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "other.go"), []byte("package main\n\nvar other int\n\ntype t struct{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out [][]string
+	contexts, _ := NewGenerator(false).pryContexts(fset, f, file)
+	for _, context := range contexts {
+		var names []string
+		for _, v := range context.Vars {
+			if expr := "&" + v.Name; v.expr() != expr {
+				t.Errorf("Expected %s got %s.", expr, v.expr())
+			}
+			names = append(names, v.Name)
+		}
+		out = append(out, names)
+	}
+	// Package level identifiers are in the symbol table of the package.
+	expected := [][]string{
+		{"a", "s"},
+		{"a", "i", "v", "x"},
+		{"a", "i", "k"},
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/d4l3k/go-pry/pry"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// tableFile is the name of the symbol table file generated into packages with
+// pry statements. External test packages get tableTestFile.
+const (
+	tableFile     = "gopry_symbols.go"
+	tableTestFile = "gopry_symbols_test.go"
+)
+
+// packageTable is the symbol table of a package with pry statements. It's
+// generated into a file of the package registering the package level
+// identifiers of the package and the exports of the imports of its injected
+// files, so pry statements only capture their local variables.
+type packageTable struct {
+	dir  string
+	fset *token.FileSet
+	// key is the path the package level identifiers are registered under.
+	key string
+	pkg *types.Package
+	// imports are the export tables of the imported packages by import path.
+	imports map[string]*exportTable
+}
+
+// table returns the symbol table of the package pkg in dir, creating it for
+// the first file of the package.
+func (g *Generator) table(dir string, fset *token.FileSet, pkg *types.Package) *packageTable {
+	id := dir + "\x00" + pkg.Name()
+	if t, ok := g.tables[id]; ok {
+		return t
+	}
+	if g.tables == nil {
+		g.tables = map[string]*packageTable{}
+	}
+	t := &packageTable{
+		dir:     dir,
+		fset:    fset,
+		key:     g.packagePath(dir, pkg.Name()),
+		pkg:     pkg,
+		imports: map[string]*exportTable{},
+	}
+	g.tables[id] = t
+	return t
+}
+
+// packagePath returns the import path of the package named name in dir. It
+// falls back to the directory, since the path only has to be unique.
+func (g *Generator) packagePath(dir, name string) string {
+	config := g.Config
+	config.Mode = packages.NeedName
+	config.Dir = dir
+	path := dir
+	if pkgs, err := loadPackages(&config, "."); err == nil && len(pkgs) == 1 && pkgs[0].PkgPath != "" {
+		path = pkgs[0].PkgPath
+	}
+	if strings.HasSuffix(name, "_test") {
+		path += "_test"
+	}
+	return path
+}
+
+// fileName returns the name of the file the table is generated into.
+func (t *packageTable) fileName() string {
+	if strings.HasSuffix(t.pkg.Name(), "_test") {
+		return tableTestFile
+	}
+	return tableFile
+}
+
+// path returns the path of the file the table is generated into.
+func (t *packageTable) path() string {
+	return filepath.Join(t.dir, t.fileName())
+}
+
+// source returns the source of the file registering the table.
+func (t *packageTable) source() ([]byte, error) {
+	used := map[string]bool{}
+	for _, name := range t.pkg.Scope().Names() {
+		used[name] = true
+	}
+	alias := func(name string) string {
+		a := name
+		for i := 1; used[a]; i++ {
+			a = name + strconv.Itoa(i)
+		}
+		used[a] = true
+		return a
+	}
+	const pryPath = "github.com/d4l3k/go-pry/pry"
+	pryName := alias("pry")
+
+	var paths []string
+	for path := range t.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	aliases := map[string]string{}
+	for _, path := range paths {
+		if path == pryPath {
+			aliases[path] = pryName
+		} else {
+			aliases[path] = alias(t.imports[path].Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go-pry. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", t.pkg.Name())
+	fmt.Fprintf(&buf, "%s %q\n", pryName, pryPath)
+	for _, path := range paths {
+		if path != pryPath {
+			fmt.Fprintf(&buf, "%s %q\n", aliases[path], path)
+		}
+	}
+	buf.WriteString(")\n\nfunc init() {\n")
+	buf.WriteString(t.init(pryName, aliases))
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "format %s", buf.String())
+	}
+	return src, nil
+}
+
+// init returns the statements registering the table, with go-pry imported as
+// pryName and the imports as aliases.
+func (t *packageTable) init(pryName string, aliases map[string]string) string {
+	var paths []string
+	for path := range t.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "%s.RegisterPackage(%q, %q, map[string]interface{}{\n", pryName, path, t.imports[path].Name)
+		buf.WriteString(pry.SymbolEntries(aliases[path]+".", pryName, t.imports[path].Symbols))
+		buf.WriteString("})\n")
+	}
+	fmt.Fprintf(&buf, "%s.RegisterPackage(%q, %q, map[string]interface{}{\n", pryName, t.key, t.pkg.Name())
+	buf.WriteString(pry.SymbolEntries("", pryName, pry.Symbols(t.pkg, func(obj types.Object) bool {
+		return obj.Name() != "init" && obj.Name() != "_" && !inTestFile(t.fset, obj)
+	})))
+	buf.WriteString("})\n")
+	return buf.String()
+}
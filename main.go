@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/signal"
@@ -11,6 +12,8 @@ import (
 	"strings"
 
 	"github.com/d4l3k/go-pry/generate"
+	"github.com/d4l3k/go-pry/pry"
+	_ "github.com/d4l3k/go-pry/stdlib"
 	"github.com/pkg/errors"
 )
 
@@ -35,7 +38,7 @@ func run() error {
 
 	// FLAGS
 	imports := flag.String("i", "fmt,math", "packages to import, comma seperated")
-	revert := flag.Bool("r", true, "whether to revert changes on exit")
+	flag.Bool("r", true, "deprecated and ignored, source files are only modified by apply")
 	execute := flag.String("e", "", "statements to execute")
 	generatePath := flag.String("generate", "", "the path to generate a go-pry injected file - EXPERIMENTAL")
 	debug := flag.Bool("d", false, "display debug statements")
@@ -49,7 +52,12 @@ func run() error {
 		fmt.Println("You can execute go commands as normal and go-pry will take care of generating the pry code.")
 		fmt.Println("Running go-pry with no arguments will drop you into an interactive REPL.")
 		flag.PrintDefaults()
-		fmt.Println("  revert: cleans up go-pry generated files if not automatically done")
+		fmt.Println("  apply: injects pry into the source files in place, for other build tools")
+		fmt.Println("  revert [files]: restores the source files modified by apply, or only the given ones")
+		fmt.Println("  status: lists the source files modified by apply and not reverted yet")
+		fmt.Println("  script <file.go> [args...]: interprets a package main file without compiling it")
+		fmt.Println("  extract [-o file] [-pkg name] <pkgpath>...: generates symbol tables for pry.RegisterPackage")
+		fmt.Println("  cache clean: removes the cached export tables and plugins of imported packages")
 	}
 	flag.Parse()
 
@@ -61,9 +69,36 @@ func run() error {
 		if len(*generatePath) > 0 {
 			return g.GenerateFile(imports, *execute, *generatePath)
 		}
+		if scope, ok := replScope(g, imports); ok {
+			if _, err := scope.InterpretString(*execute); err != nil {
+				return errors.Wrap(err, "execute")
+			}
+			pry.REPL(scope)
+			return nil
+		}
 		return g.GenerateAndExecuteFile(ctx, imports, *execute)
 	}
 
+	if cmdArgs[0] == "extract" {
+		return extract(g, cmdArgs[1:])
+	}
+	if cmdArgs[0] == "cache" {
+		return cache(g, cmdArgs[1:])
+	}
+	if cmdArgs[0] == "script" {
+		return script(ctx, cmdArgs[1:])
+	}
+	if isScript(cmdArgs[0]) {
+		// Lets scripts use a plain "#!/usr/bin/env go-pry" line, since Linux
+		// passes the arguments of a shebang as one.
+		return script(ctx, cmdArgs)
+	}
+
+	// Load packages with the build flags and environment of the go command.
+	g.ConfigureBuild(cmdArgs[1:], nil)
+
+	// revert and status work on the modules of the files given, or of the
+	// current directory.
 	goDirs := []string{}
 	for _, arg := range cmdArgs {
 		if strings.HasSuffix(arg, ".go") {
@@ -78,53 +113,47 @@ func run() error {
 		goDirs = []string{dir}
 	}
 
-	processedFiles := []string{}
-	modifiedFiles := []string{}
-
 	if cmdArgs[0] == "revert" {
+		var files []string
+		for _, arg := range cmdArgs[1:] {
+			if strings.HasSuffix(arg, ".go") {
+				files = append(files, arg)
+			}
+		}
+		if len(files) > 0 {
+			return g.RevertPry(files)
+		}
 		fmt.Println("REVERTING PRY")
+		var failed []string
 		for _, dir := range goDirs {
-			filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-				if strings.HasSuffix(path, ".gopry") {
-					processed := false
-					for _, file := range processedFiles {
-						if file == path {
-							processed = true
-						}
-					}
-					if !processed {
-						base := filepath.Base(path)
-						newPath := filepath.Dir(path) + "/" + base[1:len(base)-3]
-						modifiedFiles = append(modifiedFiles, newPath)
-					}
-				}
-				return nil
-			})
+			if err := g.RevertAll(dir); err != nil {
+				failed = append(failed, err.Error())
+			}
+		}
+		if len(failed) > 0 {
+			return errors.New(strings.Join(failed, "\n"))
 		}
-		return g.RevertPry(modifiedFiles)
+		return nil
+	}
+	if cmdArgs[0] == "status" {
+		return status(g, goDirs)
 	}
 
-	testsRequired := cmdArgs[0] == "test"
-	for _, dir := range goDirs {
-		if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-			if !testsRequired && strings.HasSuffix(path, "_test.go") || !strings.HasSuffix(path, ".go") || strings.Contains(path, "vendor/") {
-				return nil
-			}
-			for _, file := range processedFiles {
-				if file == path {
-					return nil
-				}
-			}
-			file, err := g.InjectPry(path)
-			if err != nil {
-				return errors.Wrap(err, "inject")
-			}
-			if file != "" {
-				modifiedFiles = append(modifiedFiles, path)
-			}
-			return nil
-		}); err != nil {
-			return err
+	// Files are only injected in place by apply. Other commands build
+	// injected copies through an overlay, leaving the source files untouched.
+	inject := g.OverlayPry
+	if cmdArgs[0] == "apply" {
+		inject = g.InjectPry
+	}
+	defer g.Cleanup()
+
+	files, err := g.PackageFiles(cmdArgs[0], cmdArgs[1:])
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, err := inject(file); err != nil {
+			return errors.Wrap(err, "inject")
 		}
 	}
 
@@ -132,14 +161,104 @@ func run() error {
 		return nil
 	}
 
-	if err := g.ExecuteGoCmd(ctx, cmdArgs, nil); err != nil {
-		return err
-	}
+	return g.ExecuteGoCmd(ctx, cmdArgs, nil)
+}
 
-	if *revert {
-		if err := g.RevertPry(modifiedFiles); err != nil {
+// status prints the files injected in place in the modules of dirs.
+func status(g *generate.Generator, dirs []string) error {
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		injections, err := g.Status(dir)
+		if err != nil {
 			return err
 		}
+		for _, inj := range injections {
+			if seen[inj.File] {
+				continue
+			}
+			seen[inj.File] = true
+			fmt.Printf("%s\t%s\n", inj.State, inj.File)
+		}
+	}
+	if len(seen) == 0 {
+		fmt.Println("No files are injected.")
 	}
 	return nil
 }
+
+// replScope returns a scope with the imports bound from the symbol tables
+// built into go-pry. It returns false if an import has no table, in which case
+// the REPL has to be compiled.
+func replScope(g *generate.Generator, imports []string) (*pry.Scope, bool) {
+	scope := pry.NewScope()
+	for _, imp := range imports {
+		if len(imp) == 0 {
+			continue
+		}
+		pkg, ok := pry.LookupPackage(imp)
+		if !ok {
+			g.Debug("No symbol table for %s, compiling the REPL\n", imp)
+			return nil, false
+		}
+		scope.Vals[pkg.Name] = pkg
+	}
+	return scope, true
+}
+
+// script interprets the Go file args[0] with the rest of args as its
+// arguments. Scripts calling os.Exit exit directly.
+func script(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("script: no file given")
+	}
+	src, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return errors.Wrap(err, "script")
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	os.Args = args
+	return pry.NewScope().RunScript(ctx, args[0], src)
+}
+
+// isScript reports whether path is a file starting with a #! line, which is
+// run by the kernel with its path as the first argument.
+func isScript(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	buf := make([]byte, 2)
+	n, _ := f.Read(buf)
+	return n == 2 && string(buf) == "#!"
+}
+
+// cache manages the cache of export tables and plugins.
+func cache(g *generate.Generator, args []string) error {
+	if len(args) != 1 || args[0] != "clean" {
+		return errors.New("usage: go-pry cache clean")
+	}
+	return g.CleanCache()
+}
+
+// extract writes the symbol tables of the packages in args.
+func extract(g *generate.Generator, args []string) error {
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	out := flags.String("o", "", "the file to write, stdout if empty")
+	pkgName := flags.String("pkg", "main", "the package of the generated file")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		return errors.New("extract: no packages given")
+	}
+
+	src, err := g.Extract(*pkgName, flags.Args())
+	if err != nil {
+		return errors.Wrap(err, "extract")
+	}
+	if *out == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(*out, src, 0644)
+}
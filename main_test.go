@@ -1,8 +1,92 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 )
 
 func TestBackupRestore(t *testing.T) {
 }
+
+const testScript = `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Println("hello", os.Args[1])
+}
+`
+
+func TestScriptCLI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("scripts need #! support")
+	}
+	dir, err := ioutil.TempDir("", "go-pry-script")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	build := exec.Command("go", "build", "-o", dir, ".")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+	goPry := filepath.Join(dir, "go-pry")
+	env := append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	for name, src := range map[string]string{
+		"shebang":  "#!/usr/bin/env go-pry\n" + testScript,
+		"plain.go": testScript,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run := func(name string, args ...string) (string, error) {
+		cmd := exec.Command(name, args...)
+		cmd.Env = env
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	// The kernel runs the script with its path as the first argument.
+	if out, err := run(filepath.Join(dir, "shebang"), "world"); err != nil || out != "hello world\n" {
+		t.Errorf("Expected the executable script to print hello world, got %q, %v", out, err)
+	}
+	if out, err := run(goPry, "script", filepath.Join(dir, "plain.go"), "script"); err != nil || out != "hello script\n" {
+		t.Errorf("Expected the script subcommand to print hello script, got %q, %v", out, err)
+	}
+	// Only files starting with #! are run as scripts without the subcommand.
+	if out, err := run(goPry, filepath.Join(dir, "plain.go"), "x"); err == nil || strings.Contains(out, "hello") {
+		t.Errorf("Expected a .go file without #! not to be run as a script, got %q, %v", out, err)
+	}
+	if out, err := run(goPry, "script", filepath.Join(dir, "missing.go")); err == nil || !strings.Contains(out, "no such file") {
+		t.Errorf("Expected a missing script to fail, got %q, %v", out, err)
+	}
+}
+
+func TestDeprecatedRevertFlag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pry-flags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	build := exec.Command("go", "build", "-o", dir, ".")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+
+	// -r is still accepted so existing invocations keep working.
+	cmd := exec.Command(filepath.Join(dir, "go-pry"), "-r=false", "status")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil || !strings.Contains(string(out), "No files are injected") {
+		t.Errorf("Expected -r to be ignored, got %q, %v", out, err)
+	}
+}
@@ -19,10 +19,6 @@ func (scope *Scope) SuggestionsGoCode(line string, index int) ([]string, error)
 	var suggestions []string
 	var code string
 	for name, file := range scope.Files {
-		moddedName := filepath.Dir(name) + "/." + filepath.Base(name) + "pry"
-		if scope.path == moddedName {
-			name = moddedName
-		}
 		if name == scope.path {
 			ast.Walk(walker(func(n ast.Node) bool {
 				switch s := n.(type) {
@@ -31,7 +27,7 @@ func (scope *Scope) SuggestionsGoCode(line string, index int) ([]string, error)
 						pos := scope.fset.Position(stmt.Pos())
 						if pos.Line == scope.line {
 							r := scope.Render(stmt)
-							if strings.HasPrefix(r, "pry.Apply") {
+							if isPryCall(r) {
 								var iStmt []ast.Stmt
 								iStmt = append(iStmt, ast.Stmt(&ast.ExprStmt{X: ast.NewIdent(placeholder)}))
 								oldList := make([]ast.Stmt, len(s.List))
@@ -0,0 +1,316 @@
+package pry
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+)
+
+// BindingKind tells where a binding in a scope came from.
+type BindingKind int
+
+// The kinds of bindings.
+const (
+	// DefinedBinding is a variable defined at the prompt or with Set.
+	DefinedBinding BindingKind = iota
+	// CapturedBinding is a variable of the program captured by pry.Apply.
+	CapturedBinding
+	// PackageBinding is an imported package.
+	PackageBinding
+)
+
+func (k BindingKind) String() string {
+	switch k {
+	case DefinedBinding:
+		return "defined"
+	case CapturedBinding:
+		return "captured"
+	case PackageBinding:
+		return "package"
+	}
+	return "unknown"
+}
+
+// Binding is a name bound in a scope.
+type Binding struct {
+	Name string
+	Kind BindingKind
+	// Value is the current value, as returned by Get.
+	Value interface{}
+	// Type is the static type of the binding when it's known and the type of
+	// the stored value otherwise. It's nil for untyped nil values.
+	Type reflect.Type
+	// Depth is the number of parents between the walked scope and the scope
+	// holding the binding.
+	Depth int
+}
+
+// markCaptured records the current bindings of scope as captured from the
+// program.
+func (scope *Scope) markCaptured() {
+	scope.Lock()
+	defer scope.Unlock()
+	if scope.captured == nil {
+		scope.captured = map[string]bool{}
+	}
+	for name, v := range scope.Vals {
+		if _, isPkg := v.(Package); !isPkg && name != "_pryScope" {
+			scope.captured[name] = true
+		}
+	}
+}
+
+// bind stores val as the binding name defined in scope. The caller must hold
+// the lock of scope.
+func (scope *Scope) bind(name string, val interface{}) {
+	scope.Vals[name] = val
+	delete(scope.captured, name)
+}
+
+// Keys returns all keys in scope
+func (scope *Scope) Keys() (keys []string) {
+	currentScope := scope
+	for currentScope != nil {
+		currentScope.Lock()
+		for k := range currentScope.Vals {
+			keys = append(keys, k)
+		}
+		currentScope.Unlock()
+		currentScope = currentScope.Parent
+	}
+	return
+}
+
+// Delete removes the binding name from the nearest scope holding it. It
+// reports whether a binding was removed.
+func (scope *Scope) Delete(name string) bool {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		_, ok := s.Vals[name]
+		if ok {
+			delete(s.Vals, name)
+			delete(s.captured, name)
+		}
+		s.Unlock()
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk calls fn for each binding of scope and its parents, starting with the
+// innermost scope. Bindings of a scope are visited in name order and shadowed
+// bindings are visited too. Walk stops when fn returns false.
+func (scope *Scope) Walk(fn func(b Binding) bool) {
+	depth := 0
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		names := make([]string, 0, len(s.Vals))
+		for name := range s.Vals {
+			names = append(names, name)
+		}
+		s.Unlock()
+		sort.Strings(names)
+		for _, name := range names {
+			b, ok := s.binding(name)
+			if !ok {
+				continue
+			}
+			b.Depth = depth
+			if !fn(b) {
+				return
+			}
+		}
+		depth++
+	}
+}
+
+// binding describes the binding name held by scope itself.
+func (scope *Scope) binding(name string) (Binding, bool) {
+	scope.Lock()
+	stored, ok := scope.Vals[name]
+	captured := scope.captured[name]
+	scope.Unlock()
+	if !ok {
+		return Binding{}, false
+	}
+	b := Binding{Name: name, Kind: DefinedBinding, Value: stored}
+	if captured {
+		b.Kind = CapturedBinding
+	}
+	if _, isPkg := stored.(Package); isPkg {
+		b.Kind = PackageBinding
+	}
+	if v := reflect.ValueOf(stored); v.Kind() == reflect.Ptr && !v.IsNil() && !isType(stored) {
+		b.Value = v.Elem().Interface()
+		b.Type = v.Type().Elem()
+	} else if stored != nil {
+		b.Type = v.Type()
+	}
+	if t, ok := scope.staticTypeOf(name); ok {
+		b.Type = t
+	}
+	return b, true
+}
+
+// TypeOf returns the type of the binding name: its static type when the scope
+// has been type checked, otherwise the type of its stored value.
+func (scope *Scope) TypeOf(name string) (reflect.Type, bool) {
+	for s := scope; s != nil; s = s.Parent {
+		if b, ok := s.binding(name); ok {
+			return b.Type, true
+		}
+	}
+	return nil, false
+}
+
+// staticTypeOf returns the static type of the variable name at the pry
+// statement. It's only known by the scope that was type checked.
+func (scope *Scope) staticTypeOf(name string) (reflect.Type, bool) {
+	scope.Lock()
+	pkg, fset, line := scope.pkg, scope.fset, scope.line
+	scope.Unlock()
+	if pkg == nil || fset == nil {
+		return nil, false
+	}
+	file := scope.pryFile()
+	if file == nil {
+		return nil, false
+	}
+	tokFile := fset.File(file.Pos())
+	if tokFile == nil || line < 1 || line > tokFile.LineCount() {
+		return nil, false
+	}
+	inner := pkg.Scope().Innermost(tokFile.LineStart(line))
+	if inner == nil {
+		return nil, false
+	}
+	_, obj := inner.LookupParent(name, token.NoPos)
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return nil, false
+	}
+	return scope.ReflectType(v.Type())
+}
+
+// Snapshot returns a copy of scope and its parents. Variables defined in the
+// scopes get their own storage in the copy, so assignments in one don't affect
+// the other, while captured variables and packages are shared with the
+// program. The type checking state is copied too.
+func (scope *Scope) Snapshot() *Scope {
+	var parent *Scope
+	if scope.Parent != nil {
+		parent = scope.Parent.Snapshot()
+	}
+
+	scope.Lock()
+	defer scope.Unlock()
+	s := &Scope{
+		Vals:       map[string]interface{}{},
+		Parent:     parent,
+		Engine:     scope.Engine,
+		info:       scope.info,
+		pkg:        scope.pkg,
+		path:       scope.path,
+		line:       scope.line,
+		fset:       scope.fset,
+		isFunction: scope.isFunction,
+		trace:      scope.trace,
+		source:     scope.source,
+	}
+	if scope.Files != nil {
+		s.Files = map[string]*ast.File{}
+		for name, file := range scope.Files {
+			s.Files[name] = copyAST(file).(*ast.File)
+		}
+	}
+	if scope.config != nil {
+		config := *scope.config
+		s.config = &config
+	}
+	if scope.budget != nil {
+		s.budget = &budget{Limits: scope.budget.Limits}
+	}
+	if scope.captured != nil {
+		s.captured = map[string]bool{}
+		for name := range scope.captured {
+			s.captured[name] = true
+		}
+	}
+	for name, stored := range scope.Vals {
+		v := reflect.ValueOf(stored)
+		switch {
+		case name == "_pryScope":
+			stored = &s
+		case !scope.captured[name] && v.Kind() == reflect.Ptr && !v.IsNil() && !isType(stored):
+			ptr := reflect.New(v.Type().Elem())
+			ptr.Elem().Set(v.Elem())
+			stored = ptr.Interface()
+		}
+		s.Vals[name] = stored
+	}
+	return s
+}
+
+// copyAST returns a deep copy of node. The statements of the prompt are added
+// to the files of a scope when they are type checked, so scopes can't share
+// them.
+func copyAST(node ast.Node) ast.Node {
+	copied := deepCopy(reflect.ValueOf(node), map[uintptr]reflect.Value{})
+	return copied.Interface().(ast.Node)
+}
+
+func deepCopy(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := seen[v.Pointer()]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+	}
+	return v
+}
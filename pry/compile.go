@@ -0,0 +1,1213 @@
+package pry
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotCompilable is returned by Compile when a node uses a construct the
+// compiler doesn't support. Such nodes can still be evaluated by Interpret.
+var ErrNotCompilable = errors.New("node can't be compiled")
+
+var intType = reflect.TypeOf(int(0))
+
+// branch reports how a compiled statement finished.
+type branch int
+
+const (
+	branchNone branch = iota
+	branchBreak
+	branchContinue
+	branchReturn
+)
+
+// frame holds the variables declared by one run of a compiled program.
+type frame struct {
+	slots  []reflect.Value
+	ret    interface{}
+	ctx    context.Context
+	budget *budget
+}
+
+type compiledExpr func(fr *frame) reflect.Value
+
+type compiledStmt func(fr *frame) (interface{}, branch)
+
+// runtimePanic carries an error raised while running compiled code up to
+// Program.Run.
+type runtimePanic struct {
+	err error
+}
+
+func throw(err error) {
+	panic(runtimePanic{err})
+}
+
+// checkpoint aborts the program if its evaluation has been cancelled or ran
+// out of steps.
+func (fr *frame) checkpoint() {
+	if err := checkpoint(fr.ctx, fr.budget); err != nil {
+		throw(err)
+	}
+}
+
+// Program is an AST compiled into a tree of closures. Variables declared by
+// the program are resolved to slots at compile time, and variables from the
+// scope are bound to their storage once instead of being looked up on every
+// access.
+type Program struct {
+	scope    *Scope
+	run      compiledStmt
+	numSlots int
+	exports  map[string]int
+}
+
+// Compile compiles a type checked node into a Program that evaluates it
+// against scope. It returns ErrNotCompilable if the node contains constructs
+// only the tree walking interpreter handles.
+func (scope *Scope) Compile(node ast.Node) (*Program, error) {
+	c := &compiler{scope: scope}
+	c.push()
+	var run compiledStmt
+	var err error
+	switch n := node.(type) {
+	case *ast.BlockStmt:
+		run, err = c.stmtList(n.List)
+	case ast.Stmt:
+		run, err = c.stmt(n)
+	case ast.Expr:
+		run, err = c.stmt(&ast.ExprStmt{X: n})
+	default:
+		err = ErrNotCompilable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		scope:    scope,
+		run:      run,
+		numSlots: c.numSlots,
+		exports:  c.blocks[0],
+	}, nil
+}
+
+// Run executes the program. Variables declared at the top level of the
+// program are added to the scope afterwards.
+func (p *Program) Run() (v interface{}, err error) {
+	fr := &frame{
+		slots:  make([]reflect.Value, p.numSlots),
+		ctx:    p.scope.context(),
+		budget: p.scope.limits(),
+	}
+	defer func() {
+		p.export(fr)
+		if r := recover(); r != nil {
+			rp, ok := r.(runtimePanic)
+			if !ok {
+				panic(r)
+			}
+			v, err = nil, rp.err
+		}
+	}()
+	v, br := p.run(fr)
+	switch br {
+	case branchReturn:
+		return fr.ret, nil
+	case branchBreak:
+		return nil, ErrBranchBreak
+	case branchContinue:
+		return nil, ErrBranchContinue
+	}
+	return v, nil
+}
+
+func (p *Program) export(fr *frame) {
+	p.scope.Lock()
+	defer p.scope.Unlock()
+	for name, slot := range p.exports {
+		if v := fr.slots[slot]; v.IsValid() {
+			p.scope.bind(name, v.Addr().Interface())
+		} else {
+			p.scope.bind(name, nil)
+		}
+	}
+}
+
+type compiler struct {
+	scope    *Scope
+	blocks   []map[string]int
+	numSlots int
+}
+
+func (c *compiler) push() {
+	c.blocks = append(c.blocks, map[string]int{})
+}
+
+func (c *compiler) pop() {
+	c.blocks = c.blocks[:len(c.blocks)-1]
+}
+
+func (c *compiler) lookup(name string) (int, bool) {
+	for i := len(c.blocks) - 1; i >= 0; i-- {
+		if slot, ok := c.blocks[i][name]; ok {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+// declare returns the slot for name in the innermost block, allocating it if
+// it wasn't declared in that block yet.
+func (c *compiler) declare(name string) int {
+	block := c.blocks[len(c.blocks)-1]
+	if slot, ok := block[name]; ok {
+		return slot
+	}
+	slot := c.numSlots
+	c.numSlots++
+	block[name] = slot
+	return slot
+}
+
+// usesSlots returns whether node refers to any variable declared by the
+// program. Nodes that don't can be handed to the tree walker unchanged.
+func (c *compiler) usesSlots(node ast.Node) bool {
+	uses := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			if _, ok := c.lookup(ident.Name); ok {
+				uses = true
+			}
+		}
+		return !uses
+	})
+	return uses
+}
+
+func iface(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func constExpr(v interface{}) compiledExpr {
+	rv := reflect.ValueOf(v)
+	return func(*frame) reflect.Value { return rv }
+}
+
+func (c *compiler) stmtList(list []ast.Stmt) (compiledStmt, error) {
+	stmts := make([]compiledStmt, len(list))
+	for i, s := range list {
+		var err error
+		if stmts[i], err = c.stmt(s); err != nil {
+			return nil, err
+		}
+	}
+	return func(fr *frame) (interface{}, branch) {
+		var last interface{}
+		for _, s := range stmts {
+			out, br := s(fr)
+			if br != branchNone {
+				return out, br
+			}
+			last = out
+		}
+		return last, branchNone
+	}, nil
+}
+
+func (c *compiler) stmt(s ast.Stmt) (compiledStmt, error) {
+	switch s := s.(type) {
+	case *ast.BlockStmt:
+		c.push()
+		defer c.pop()
+		return c.stmtList(s.List)
+
+	case *ast.ExprStmt:
+		x, err := c.expr(s.X)
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *frame) (interface{}, branch) {
+			return iface(x(fr)), branchNone
+		}, nil
+
+	case *ast.AssignStmt:
+		return c.assign(s)
+
+	case *ast.IncDecStmt:
+		lv, err := c.lvalue(s.X)
+		if err != nil {
+			return nil, err
+		}
+		op := token.ADD
+		if s.Tok == token.DEC {
+			op = token.SUB
+		}
+		return func(fr *frame) (interface{}, branch) {
+			r := lv(fr)
+			x := r.get()
+			one := reflect.ValueOf(1)
+			if x.Kind() != reflect.Int && one.Type().ConvertibleTo(x.Type()) {
+				one = one.Convert(x.Type())
+			}
+			v := binaryOp(x, one, op)
+			r.set(v)
+			return iface(v), branchNone
+		}, nil
+
+	case *ast.DeclStmt:
+		decl, ok := s.Decl.(*ast.GenDecl)
+		if !ok || (decl.Tok != token.VAR && decl.Tok != token.CONST) {
+			return c.delegateStmt(s)
+		}
+		var specs []compiledStmt
+		for _, spec := range decl.Specs {
+			vs, err := c.valueSpec(spec.(*ast.ValueSpec))
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, vs)
+		}
+		return func(fr *frame) (interface{}, branch) {
+			for _, vs := range specs {
+				vs(fr)
+			}
+			return nil, branchNone
+		}, nil
+
+	case *ast.IfStmt:
+		c.push()
+		defer c.pop()
+		var init compiledStmt
+		if s.Init != nil {
+			var err error
+			if init, err = c.stmt(s.Init); err != nil {
+				return nil, err
+			}
+		}
+		cond, err := c.expr(s.Cond)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.stmt(s.Body)
+		if err != nil {
+			return nil, err
+		}
+		var els compiledStmt
+		if s.Else != nil {
+			if els, err = c.stmt(s.Else); err != nil {
+				return nil, err
+			}
+		}
+		return func(fr *frame) (interface{}, branch) {
+			if init != nil {
+				init(fr)
+			}
+			if cond(fr).Bool() {
+				return body(fr)
+			}
+			if els != nil {
+				return els(fr)
+			}
+			return nil, branchNone
+		}, nil
+
+	case *ast.ForStmt:
+		c.push()
+		defer c.pop()
+		var init, post, body compiledStmt
+		var cond compiledExpr
+		var err error
+		if s.Init != nil {
+			if init, err = c.stmt(s.Init); err != nil {
+				return nil, err
+			}
+		}
+		if s.Cond != nil {
+			if cond, err = c.expr(s.Cond); err != nil {
+				return nil, err
+			}
+		}
+		if s.Post != nil {
+			if post, err = c.stmt(s.Post); err != nil {
+				return nil, err
+			}
+		}
+		if body, err = c.stmt(s.Body); err != nil {
+			return nil, err
+		}
+		return func(fr *frame) (interface{}, branch) {
+			if init != nil {
+				init(fr)
+			}
+			var last interface{}
+			for cond == nil || cond(fr).Bool() {
+				fr.checkpoint()
+				out, br := body(fr)
+				if br == branchBreak {
+					break
+				} else if br == branchReturn {
+					return out, br
+				}
+				last = out
+				if post != nil {
+					post(fr)
+				}
+			}
+			return last, branchNone
+		}, nil
+
+	case *ast.RangeStmt:
+		return c.rangeStmt(s)
+
+	case *ast.BranchStmt:
+		if s.Label != nil {
+			return nil, ErrNotCompilable
+		}
+		switch s.Tok {
+		case token.BREAK:
+			return func(*frame) (interface{}, branch) { return nil, branchBreak }, nil
+		case token.CONTINUE:
+			return func(*frame) (interface{}, branch) { return nil, branchContinue }, nil
+		}
+		return nil, ErrNotCompilable
+
+	case *ast.ReturnStmt:
+		results, err := c.exprs(s.Results)
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *frame) (interface{}, branch) {
+			switch len(results) {
+			case 0:
+				fr.ret = nil
+			case 1:
+				fr.ret = iface(results[0](fr))
+			default:
+				rets := make([]interface{}, len(results))
+				for i, r := range results {
+					rets[i] = iface(r(fr))
+				}
+				fr.ret = rets
+			}
+			return fr.ret, branchReturn
+		}, nil
+
+	default:
+		return c.delegateStmt(s)
+	}
+}
+
+// delegateStmt hands a statement the compiler doesn't support to the tree
+// walker, as long as it doesn't need any of the program's variables.
+func (c *compiler) delegateStmt(s ast.Stmt) (compiledStmt, error) {
+	if c.usesSlots(s) {
+		return nil, ErrNotCompilable
+	}
+	scope := c.scope
+	return func(*frame) (interface{}, branch) {
+		out, err := scope.Interpret(s)
+		switch err {
+		case nil:
+			return out, branchNone
+		case ErrBranchBreak:
+			return nil, branchBreak
+		case ErrBranchContinue:
+			return nil, branchContinue
+		}
+		throw(err)
+		return nil, branchNone
+	}, nil
+}
+
+func (c *compiler) valueSpec(spec *ast.ValueSpec) (compiledStmt, error) {
+	var zero reflect.Value
+	if spec.Type != nil {
+		if c.usesSlots(spec.Type) {
+			return nil, ErrNotCompilable
+		}
+		typ, err := c.scope.Interpret(spec.Type)
+		if err != nil {
+			return nil, err
+		}
+		rType, ok := typ.(reflect.Type)
+		if !ok {
+			return nil, ErrNotCompilable
+		}
+		if err := c.scope.checkArray(rType); err != nil {
+			return nil, err
+		}
+		zero = reflect.Zero(rType)
+	}
+	values, err := c.exprs(spec.Values)
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]func(*frame, reflect.Value), len(spec.Names))
+	for i, name := range spec.Names {
+		defs[i] = c.define(name)
+	}
+	return func(fr *frame) (interface{}, branch) {
+		for i, def := range defs {
+			if i < len(values) {
+				def(fr, values[i](fr))
+			} else {
+				def(fr, zero)
+			}
+		}
+		return nil, branchNone
+	}, nil
+}
+
+// define declares ident in the current block and returns a function that
+// initializes it.
+func (c *compiler) define(ident *ast.Ident) func(*frame, reflect.Value) {
+	if ident.Name == "_" {
+		return func(*frame, reflect.Value) {}
+	}
+	rType, typed := c.scope.objectType(ident)
+	slot := c.declare(ident.Name)
+	return func(fr *frame, v reflect.Value) {
+		typ := rType
+		if !typed {
+			if !v.IsValid() {
+				fr.slots[slot] = reflect.Value{}
+				return
+			}
+			typ = v.Type()
+		}
+		storage := reflect.New(typ).Elem()
+		if v.IsValid() {
+			storage.Set(convertAssignable(v, typ))
+		}
+		fr.slots[slot] = storage
+	}
+}
+
+func (c *compiler) assign(s *ast.AssignStmt) (compiledStmt, error) {
+	rhs, err := c.exprs(s.Rhs)
+	if err != nil {
+		return nil, err
+	}
+	split := len(s.Rhs) == 1 && len(s.Lhs) > 1
+	if !split && len(s.Rhs) != len(s.Lhs) {
+		return nil, ErrNotCompilable
+	}
+
+	// Left hand sides are compiled after the right hand sides so a
+	// declaration doesn't shadow a variable used to initialize it.
+	assigns := make([]func(*frame, reflect.Value), len(s.Lhs))
+	for i, lhs := range s.Lhs {
+		if ident, ok := lhs.(*ast.Ident); ok && ident.Name == "_" {
+			assigns[i] = func(*frame, reflect.Value) {}
+			continue
+		}
+		if s.Tok == token.DEFINE {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok {
+				return nil, ErrNotCompilable
+			}
+			if _, declared := c.blocks[len(c.blocks)-1][ident.Name]; !declared {
+				assigns[i] = c.define(ident)
+				continue
+			}
+		}
+		lv, err := c.lvalue(lhs)
+		if err != nil {
+			return nil, err
+		}
+		if s.Tok == token.ASSIGN || s.Tok == token.DEFINE {
+			assigns[i] = func(fr *frame, v reflect.Value) {
+				lv(fr).set(v)
+			}
+			continue
+		}
+		op := DeAssign(s.Tok)
+		assigns[i] = func(fr *frame, v reflect.Value) {
+			r := lv(fr)
+			r.set(binaryOp(r.get(), v, op))
+		}
+	}
+
+	return func(fr *frame) (interface{}, branch) {
+		values := make([]reflect.Value, len(rhs))
+		for i, r := range rhs {
+			values[i] = r(fr)
+		}
+		if split {
+			v := values[0]
+			if v.Kind() != reflect.Slice || v.Len() != len(s.Lhs) {
+				throw(fmt.Errorf("assignment count mismatch: %d = %d", len(s.Lhs), len(values)))
+			}
+			values = make([]reflect.Value, v.Len())
+			for i := range values {
+				values[i] = reflect.ValueOf(v.Index(i).Interface())
+			}
+		}
+		for i, assign := range assigns {
+			assign(fr, values[i])
+		}
+		if len(values) > 1 {
+			out := make([]interface{}, len(values))
+			for i, v := range values {
+				out[i] = iface(v)
+			}
+			return out, branchNone
+		}
+		return iface(values[0]), branchNone
+	}, nil
+}
+
+func (c *compiler) rangeStmt(s *ast.RangeStmt) (compiledStmt, error) {
+	x, err := c.expr(s.X)
+	if err != nil {
+		return nil, err
+	}
+	c.push()
+	defer c.pop()
+
+	var setKey, setValue func(*frame, reflect.Value)
+	for _, v := range []struct {
+		expr ast.Expr
+		set  *func(*frame, reflect.Value)
+	}{{s.Key, &setKey}, {s.Value, &setValue}} {
+		if v.expr == nil {
+			continue
+		}
+		if ident, ok := v.expr.(*ast.Ident); ok && ident.Name == "_" {
+			continue
+		}
+		if s.Tok == token.DEFINE {
+			ident, ok := v.expr.(*ast.Ident)
+			if !ok {
+				return nil, ErrNotCompilable
+			}
+			*v.set = c.define(ident)
+			continue
+		}
+		lv, err := c.lvalue(v.expr)
+		if err != nil {
+			return nil, err
+		}
+		*v.set = func(fr *frame, val reflect.Value) {
+			lv(fr).set(val)
+		}
+	}
+
+	body, err := c.stmt(s.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// iterate runs the body once and reports whether the loop should stop.
+	iterate := func(fr *frame, key, value func() reflect.Value) (bool, interface{}, branch) {
+		fr.checkpoint()
+		if setKey != nil {
+			setKey(fr, key())
+		}
+		if setValue != nil {
+			setValue(fr, value())
+		}
+		out, br := body(fr)
+		switch br {
+		case branchBreak:
+			return true, nil, branchNone
+		case branchReturn:
+			return true, out, br
+		}
+		return false, nil, branchNone
+	}
+
+	return func(fr *frame) (interface{}, branch) {
+		rv := x(fr)
+		switch rv.Kind() {
+		case reflect.Array, reflect.Slice:
+			for i := 0; i < rv.Len(); i++ {
+				i := i
+				stop, out, br := iterate(fr,
+					func() reflect.Value { return reflect.ValueOf(i) },
+					func() reflect.Value { return rv.Index(i) })
+				if stop {
+					return out, br
+				}
+			}
+		case reflect.Map:
+			for _, key := range rv.MapKeys() {
+				key := key
+				stop, out, br := iterate(fr,
+					func() reflect.Value { return key },
+					func() reflect.Value { return rv.MapIndex(key) })
+				if stop {
+					return out, br
+				}
+			}
+		default:
+			throw(fmt.Errorf("ranging on %s is unsupported", rv.Kind().String()))
+		}
+		return nil, branchNone
+	}, nil
+}
+
+// ref is a reference to an assignable location.
+type ref struct {
+	v reflect.Value
+
+	// Map entries aren't addressable and are assigned through the map.
+	m, key reflect.Value
+
+	// Slots without a value yet are assigned by replacing the slot.
+	fr   *frame
+	slot int
+}
+
+func (r ref) get() reflect.Value {
+	if r.m.IsValid() {
+		v := r.m.MapIndex(r.key)
+		if !v.IsValid() {
+			return reflect.Zero(r.m.Type().Elem())
+		}
+		return v
+	}
+	return r.v
+}
+
+func (r ref) set(v reflect.Value) {
+	switch {
+	case r.m.IsValid():
+		elem := r.m.Type().Elem()
+		if !v.IsValid() {
+			v = reflect.Zero(elem)
+		}
+		r.m.SetMapIndex(r.key, convertAssignable(v, elem))
+
+	case !v.IsValid():
+		if r.v.IsValid() {
+			r.v.Set(reflect.Zero(r.v.Type()))
+		}
+
+	case r.fr != nil && (!r.v.IsValid() || !convertAssignable(v, r.v.Type()).Type().AssignableTo(r.v.Type())):
+		// Variables declared without a static type take the type of
+		// whatever is assigned to them.
+		storage := reflect.New(v.Type()).Elem()
+		storage.Set(v)
+		r.fr.slots[r.slot] = storage
+
+	default:
+		v = convertAssignable(v, r.v.Type())
+		if !v.Type().AssignableTo(r.v.Type()) {
+			throw(fmt.Errorf("cannot use %#v (type %s) as type %s in assignment", v.Interface(), v.Type(), r.v.Type()))
+		}
+		r.v.Set(v)
+	}
+}
+
+type compiledLValue func(fr *frame) ref
+
+func (c *compiler) lvalue(e ast.Expr) (compiledLValue, error) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return c.lvalue(e.X)
+
+	case *ast.Ident:
+		if slot, ok := c.lookup(e.Name); ok {
+			return func(fr *frame) ref {
+				return ref{v: fr.slots[slot], fr: fr, slot: slot}
+			}, nil
+		}
+		storage, ok := c.scope.storage(e.Name)
+		if !ok {
+			return nil, ErrNotCompilable
+		}
+		return func(*frame) ref { return ref{v: storage} }, nil
+
+	case *ast.IndexExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		index, err := c.expr(e.Index)
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *frame) ref {
+			xv, err := derefArray(x(fr))
+			if err != nil {
+				throw(err)
+			}
+			i := index(fr)
+			switch xv.Kind() {
+			case reflect.Map:
+				return ref{m: xv, key: convertAssignable(i, xv.Type().Key())}
+			case reflect.Slice, reflect.Array:
+				idx, err := toIndex(iface(i))
+				if err != nil {
+					throw(err)
+				}
+				if err := checkIndex(idx, xv.Len()); err != nil {
+					throw(err)
+				}
+				return ref{v: xv.Index(idx)}
+			}
+			throw(errors.Errorf("cannot assign to index of %s", xv.Type()))
+			return ref{}
+		}, nil
+
+	case *ast.SelectorExpr:
+		if ident, ok := e.X.(*ast.Ident); ok {
+			if _, isSlot := c.lookup(ident.Name); !isSlot {
+				v, _ := c.scope.Get(ident.Name)
+				if pkg, ok := v.(Package); ok {
+					ptr, ok := pkg.Var(e.Sel.Name)
+					if !ok {
+						return nil, ErrNotCompilable
+					}
+					return func(*frame) ref { return ref{v: ptr.Elem()} }, nil
+				}
+			}
+		}
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		name := e.Sel.Name
+		return func(fr *frame) ref {
+			xv := x(fr)
+			if xv.Kind() == reflect.Ptr {
+				xv = xv.Elem()
+			}
+			field := xv.FieldByName(name)
+			if !field.IsValid() {
+				throw(fmt.Errorf("unknown field %#v", name))
+			}
+			return ref{v: field}
+		}, nil
+
+	case *ast.StarExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *frame) ref {
+			return ref{v: x(fr).Elem()}
+		}, nil
+	}
+	return nil, ErrNotCompilable
+}
+
+// storage returns the addressable storage of the scope variable name. Values
+// that were captured without a pointer are moved into one first. Types have
+// no storage.
+func (scope *Scope) storage(name string) (reflect.Value, bool) {
+	ptr, exists := scope.GetPointer(name)
+	if !exists || ptr == nil || isType(ptr) {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		scope.Set(name, ptr)
+		if ptr, _ = scope.GetPointer(name); ptr == nil {
+			return reflect.Value{}, false
+		}
+		v = reflect.ValueOf(ptr)
+	}
+	return v.Elem(), true
+}
+
+func (c *compiler) exprs(list []ast.Expr) ([]compiledExpr, error) {
+	out := make([]compiledExpr, len(list))
+	for i, e := range list {
+		var err error
+		if out[i], err = c.expr(e); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// expr compiles an expression. Constants and types are computed once, and
+// other values are converted to their static type like Interpret does.
+func (c *compiler) expr(e ast.Expr) (compiledExpr, error) {
+	tv, typed := c.scope.staticType(e)
+	if typed && (tv.Value != nil || tv.IsType() || tv.IsNil()) {
+		if v, ok, err := c.scope.interpretTyped(e); ok && err == nil {
+			return constExpr(v), nil
+		}
+	}
+	f, err := c.untypedExpr(e)
+	if err != nil || !typed || tv.IsType() {
+		return f, err
+	}
+	rType, ok := c.scope.ReflectType(tv.Type)
+	if !ok || rType.Kind() == reflect.Interface {
+		return f, nil
+	}
+	return func(fr *frame) reflect.Value {
+		v := f(fr)
+		if v.IsValid() && v.Type() != rType && v.Type().ConvertibleTo(rType) {
+			return v.Convert(rType)
+		}
+		return v
+	}, nil
+}
+
+func (c *compiler) untypedExpr(e ast.Expr) (compiledExpr, error) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		v, err := c.scope.Interpret(e)
+		if err != nil {
+			return nil, err
+		}
+		return constExpr(v), nil
+
+	case *ast.ParenExpr:
+		return c.expr(e.X)
+
+	case *ast.Ident:
+		if slot, ok := c.lookup(e.Name); ok {
+			return func(fr *frame) reflect.Value { return fr.slots[slot] }, nil
+		}
+		val, exists := c.scope.GetPointer(e.Name)
+		if !exists {
+			// Builtins, types and missing identifiers are left to the
+			// interpreter, which also reports the error for the latter.
+			v, err := c.scope.Interpret(e)
+			if err != nil {
+				return nil, ErrNotCompilable
+			}
+			return constExpr(v), nil
+		}
+		if v := reflect.ValueOf(val); v.Kind() == reflect.Ptr && !v.IsNil() && !isType(val) {
+			// Stored variables are read through their storage so
+			// assignments made while running are visible.
+			storage := v.Elem()
+			return func(*frame) reflect.Value { return storage }, nil
+		}
+		v, _ := c.scope.Get(e.Name)
+		return constExpr(v), nil
+
+	case *ast.BinaryExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := c.expr(e.Y)
+		if err != nil {
+			return nil, err
+		}
+		switch e.Op {
+		case token.LAND:
+			return func(fr *frame) reflect.Value {
+				if !x(fr).Bool() {
+					return reflect.ValueOf(false)
+				}
+				return reflect.ValueOf(y(fr).Bool())
+			}, nil
+		case token.LOR:
+			return func(fr *frame) reflect.Value {
+				if x(fr).Bool() {
+					return reflect.ValueOf(true)
+				}
+				return reflect.ValueOf(y(fr).Bool())
+			}, nil
+		}
+		op := e.Op
+		return func(fr *frame) reflect.Value {
+			return binaryOp(x(fr), y(fr), op)
+		}, nil
+
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			ident, isIdent := e.X.(*ast.Ident)
+			if !isIdent {
+				return c.delegateExpr(e)
+			}
+			if slot, ok := c.lookup(ident.Name); ok {
+				return func(fr *frame) reflect.Value { return fr.slots[slot].Addr() }, nil
+			}
+			storage, ok := c.scope.storage(ident.Name)
+			if !ok {
+				return nil, ErrNotCompilable
+			}
+			ptr := storage.Addr()
+			return func(*frame) reflect.Value { return ptr }, nil
+		}
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		op := e.Op
+		scope := c.scope
+		return func(fr *frame) reflect.Value {
+			xv := x(fr)
+			switch {
+			case op == token.NOT && xv.Kind() == reflect.Bool:
+				return reflect.ValueOf(!xv.Bool())
+			case op == token.SUB && xv.Type() == intType:
+				return reflect.ValueOf(-int(xv.Int()))
+			}
+			v, err := scope.ComputeUnaryOp(iface(xv), op)
+			if err != nil {
+				throw(err)
+			}
+			return reflect.ValueOf(v)
+		}, nil
+
+	case *ast.StarExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *frame) reflect.Value {
+			xv := x(fr)
+			if xv.Kind() != reflect.Ptr || xv.IsNil() {
+				throw(errors.New("runtime error: invalid memory address or nil pointer dereference"))
+			}
+			return xv.Elem()
+		}, nil
+
+	case *ast.IndexExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		index, err := c.expr(e.Index)
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *frame) reflect.Value {
+			xv, err := derefArray(x(fr))
+			if err != nil {
+				throw(err)
+			}
+			i := index(fr)
+			switch xv.Kind() {
+			case reflect.Map:
+				v := xv.MapIndex(convertAssignable(i, xv.Type().Key()))
+				if !v.IsValid() {
+					return reflect.Zero(xv.Type().Elem())
+				}
+				return v
+			case reflect.Slice, reflect.Array, reflect.String:
+				idx, err := toIndex(iface(i))
+				if err != nil {
+					throw(err)
+				}
+				if err := checkIndex(idx, xv.Len()); err != nil {
+					throw(err)
+				}
+				return xv.Index(idx)
+			}
+			throw(errors.Errorf("invalid X for IndexExpr: %#v", iface(xv)))
+			return reflect.Value{}
+		}, nil
+
+	case *ast.SliceExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		bounds := make([]compiledExpr, 3)
+		for i, b := range []ast.Expr{e.Low, e.High, e.Max} {
+			if b == nil {
+				continue
+			}
+			if bounds[i], err = c.expr(b); err != nil {
+				return nil, err
+			}
+		}
+		slice3 := e.Slice3
+		return func(fr *frame) reflect.Value {
+			xv := x(fr)
+			switch xv.Kind() {
+			case reflect.Ptr:
+				var err error
+				if xv, err = derefArray(xv); err != nil {
+					throw(err)
+				}
+			case reflect.Array:
+				if !xv.CanAddr() {
+					throw(errors.New("invalid operation: slice of unaddressable value"))
+				}
+			case reflect.String:
+				if slice3 {
+					throw(errors.New("invalid operation: 3-index slice of string"))
+				}
+			case reflect.Slice:
+			default:
+				throw(errors.Errorf("invalid X for SliceExpr: %#v", iface(xv)))
+			}
+			indexes := []int{0, xv.Len(), xv.Len()}
+			if xv.Kind() != reflect.String {
+				indexes[2] = xv.Cap()
+			}
+			for i, b := range bounds {
+				if b == nil {
+					continue
+				}
+				var err error
+				if indexes[i], err = toIndex(iface(b(fr))); err != nil {
+					throw(err)
+				}
+			}
+			v, err := sliceValue(xv, indexes[0], indexes[1], indexes[2], slice3)
+			if err != nil {
+				throw(err)
+			}
+			return reflect.ValueOf(v)
+		}, nil
+
+	case *ast.SelectorExpr:
+		if ident, ok := e.X.(*ast.Ident); ok {
+			if _, isSlot := c.lookup(ident.Name); !isSlot {
+				// Package members are resolved once.
+				v, _ := c.scope.Get(ident.Name)
+				if pkg, ok := v.(Package); ok {
+					if ptr, ok := pkg.Var(e.Sel.Name); ok {
+						return func(*frame) reflect.Value { return ptr.Elem() }, nil
+					}
+					member, ok := pkg.Functions[e.Sel.Name]
+					if !ok {
+						return nil, ErrNotCompilable
+					}
+					return constExpr(member), nil
+				}
+			}
+		}
+		x, err := c.expr(e.X)
+		if err != nil {
+			return nil, err
+		}
+		name := e.Sel.Name
+		return func(fr *frame) reflect.Value {
+			xv := x(fr)
+			if xv.Kind() == reflect.Struct && xv.CanAddr() && !xv.MethodByName(name).IsValid() {
+				if m := xv.Addr().MethodByName(name); m.IsValid() {
+					return m
+				}
+			}
+			v, err := selectMember(xv, name)
+			if err != nil {
+				throw(err)
+			}
+			return reflect.ValueOf(v)
+		}, nil
+
+	case *ast.CallExpr:
+		if e.Ellipsis.IsValid() {
+			return c.delegateExpr(e)
+		}
+		fun, err := c.expr(e.Fun)
+		if err != nil {
+			return nil, err
+		}
+		args, err := c.exprs(e.Args)
+		if err != nil {
+			return nil, err
+		}
+		scope := c.scope
+		return func(fr *frame) reflect.Value {
+			fr.checkpoint()
+			f := fun(fr)
+			if f.Kind() == reflect.Func && f.Type().NumOut() == 1 && !f.Type().IsVariadic() && f.Type().NumIn() == len(args) {
+				in := make([]reflect.Value, len(args))
+				for i, arg := range args {
+					if in[i] = arg(fr); in[i].IsValid() {
+						in[i] = convertAssignable(in[i], f.Type().In(i))
+					} else {
+						in[i] = reflect.Zero(f.Type().In(i))
+					}
+				}
+				return f.Call(in)[0]
+			}
+			in := make([]interface{}, len(args))
+			for i, arg := range args {
+				in[i] = iface(arg(fr))
+			}
+			v, err := scope.CallFunc(iface(f), in)
+			if err != nil {
+				throw(err)
+			}
+			return reflect.ValueOf(v)
+		}, nil
+
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType, *ast.StructType:
+		if c.usesSlots(e) {
+			return nil, ErrNotCompilable
+		}
+		typ, err := c.scope.Interpret(e)
+		if err != nil {
+			return nil, err
+		}
+		return constExpr(typ), nil
+	}
+	return c.delegateExpr(e)
+}
+
+// delegateExpr hands an expression the compiler doesn't support to the tree
+// walker, as long as it doesn't need any of the program's variables.
+func (c *compiler) delegateExpr(e ast.Expr) (compiledExpr, error) {
+	if c.usesSlots(e) {
+		return nil, ErrNotCompilable
+	}
+	scope := c.scope
+	return func(*frame) reflect.Value {
+		v, err := scope.Interpret(e)
+		if err != nil {
+			throw(err)
+		}
+		return reflect.ValueOf(v)
+	}, nil
+}
+
+// binaryOp computes x op y. The common int, float64 and string cases avoid
+// boxing the operands.
+func binaryOp(x, y reflect.Value, op token.Token) reflect.Value {
+	if x.IsValid() && y.IsValid() && x.Type() == y.Type() {
+		switch x.Type() {
+		case intType:
+			a, b := int(x.Int()), int(y.Int())
+			switch op {
+			case token.ADD:
+				return reflect.ValueOf(a + b)
+			case token.SUB:
+				return reflect.ValueOf(a - b)
+			case token.MUL:
+				return reflect.ValueOf(a * b)
+			case token.QUO:
+				if b == 0 {
+					throw(ErrDivisionByZero)
+				}
+				return reflect.ValueOf(a / b)
+			case token.REM:
+				if b == 0 {
+					throw(ErrDivisionByZero)
+				}
+				return reflect.ValueOf(a % b)
+			case token.LSS:
+				return reflect.ValueOf(a < b)
+			case token.GTR:
+				return reflect.ValueOf(a > b)
+			case token.LEQ:
+				return reflect.ValueOf(a <= b)
+			case token.GEQ:
+				return reflect.ValueOf(a >= b)
+			case token.EQL:
+				return reflect.ValueOf(a == b)
+			case token.NEQ:
+				return reflect.ValueOf(a != b)
+			}
+		}
+	}
+	v, err := ComputeBinaryOp(iface(x), iface(y), op)
+	if err != nil {
+		throw(err)
+	}
+	return reflect.ValueOf(v)
+}
@@ -0,0 +1,89 @@
+package pry
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInterrupted is returned when an evaluation is cancelled, usually by
+// pressing Ctrl-C at the prompt.
+var ErrInterrupted = errors.New("interrupted")
+
+// context returns the context of the evaluation running in scope.
+func (scope *Scope) context() context.Context {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		ctx := s.ctx
+		s.Unlock()
+		if ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// withContext makes ctx the context of evaluations in scope until the
+// returned function is called.
+func (scope *Scope) withContext(ctx context.Context) func() {
+	scope.Lock()
+	prev := scope.ctx
+	scope.ctx = ctx
+	scope.Unlock()
+	return func() {
+		scope.Lock()
+		scope.ctx = prev
+		scope.Unlock()
+	}
+}
+
+// checkpoint returns an error if the evaluation running in scope has been
+// cancelled or ran out of steps. It's called at every loop iteration and
+// call.
+func (scope *Scope) checkpoint() error {
+	return checkpoint(scope.context(), scope.limits())
+}
+
+func checkpoint(ctx context.Context, b *budget) error {
+	if err := interrupted(ctx, b); err != nil {
+		return err
+	}
+	return b.step()
+}
+
+// interrupted returns ErrInterrupted if the evaluation has been cancelled.
+func interrupted(ctx context.Context, b *budget) error {
+	select {
+	case <-ctx.Done():
+		return b.contextError(ctx)
+	default:
+		return nil
+	}
+}
+
+// selectCases is reflect.Select that gives up once the evaluation is
+// cancelled.
+func selectCases(ctx context.Context, b *budget, cases []reflect.SelectCase) (int, reflect.Value, bool, error) {
+	cases = append(cases, reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(ctx.Done()),
+	})
+	chosen, recv, recvOK := reflect.Select(cases)
+	if chosen == len(cases)-1 {
+		return 0, reflect.Value{}, false, b.contextError(ctx)
+	}
+	return chosen, recv, recvOK, nil
+}
+
+// recv receives from ch, giving up once the evaluation is cancelled.
+func recv(ctx context.Context, b *budget, ch reflect.Value) (reflect.Value, bool, error) {
+	_, v, ok, err := selectCases(ctx, b, []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: ch}})
+	return v, ok, err
+}
+
+// send sends v on ch, giving up once the evaluation is cancelled.
+func send(ctx context.Context, b *budget, ch, v reflect.Value) error {
+	_, _, _, err := selectCases(ctx, b, []reflect.SelectCase{{Dir: reflect.SelectSend, Chan: ch, Send: v}})
+	return err
+}
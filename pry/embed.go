@@ -0,0 +1,210 @@
+package pry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Options configure an Interpreter.
+type Options struct {
+	// Stdout receives the output of fmt.Print, fmt.Printf and fmt.Println.
+	// Nil keeps the standard output of the process.
+	Stdout io.Writer
+	// Stderr receives the output of the print and println builtins. Nil keeps
+	// the standard error of the process.
+	Stderr io.Writer
+	// AllowedPackages lists the import paths of the registered packages the
+	// input may use. All registered packages are allowed if it's nil.
+	AllowedPackages []string
+	// Limits bound the resources of each evaluation.
+	Limits Limits
+	// Engine evaluates the input. The ReflectEngine is used if it's nil.
+	Engine Engine
+}
+
+// Interpreter evaluates Go source against an environment of registered
+// packages, types and values. It lets programs embed the interpreter without
+// running the generator.
+type Interpreter struct {
+	opts  Options
+	scope *Scope
+
+	mu       sync.Mutex
+	packages map[string]*registeredPackage
+	bound    map[string]bool
+}
+
+// Result is the outcome of an evaluation.
+type Result struct {
+	// Value is the value of the last expression of the input.
+	Value interface{}
+	// Type is the type of Value, nil if Value is nil.
+	Type reflect.Type
+	// Defined lists the variables the input defined, in name order.
+	Defined []string
+	Err     error
+	// Duration is how long the evaluation took.
+	Duration time.Duration
+}
+
+// NewInterpreter returns an interpreter with an empty environment. The
+// registered packages allowed by opts can be used by the input.
+func NewInterpreter(opts Options) *Interpreter {
+	in := &Interpreter{
+		opts:     opts,
+		scope:    NewScope(),
+		packages: map[string]*registeredPackage{},
+		bound:    map[string]bool{},
+	}
+	in.scope.Engine = opts.Engine
+	in.scope.SetLimits(opts.Limits)
+	if opts.Stderr != nil {
+		in.scope.Set("print", func(args ...interface{}) { fmt.Fprint(opts.Stderr, args...) })
+		in.scope.Set("println", func(args ...interface{}) { fmt.Fprintln(opts.Stderr, args...) })
+	}
+	return in
+}
+
+// Scope returns the scope the input is evaluated in.
+func (in *Interpreter) Scope() *Scope {
+	return in.scope
+}
+
+// RegisterPackage makes a package available to this interpreter only, as
+// RegisterPackage does for all interpreters.
+func (in *Interpreter) RegisterPackage(importPath, name string, symbols map[string]interface{}) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	register(in.packages, importPath, name, symbols)
+	delete(in.bound, importPath)
+}
+
+// RegisterType makes the type of v available to the input under name. v is
+// usually a pointer to the type, such as (*T)(nil), so interface types can be
+// registered too.
+func (in *Interpreter) RegisterType(name string, v interface{}) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		t = t.Elem()
+	}
+	in.scope.Set(name, t)
+}
+
+// RegisterValue makes v available to the input as the variable name.
+// Functions are called natively and pointers are dereferenced by the input as
+// usual.
+func (in *Interpreter) RegisterValue(name string, v interface{}) {
+	in.scope.Set(name, v)
+}
+
+// allowed reports whether the input may use the package with the import
+// path.
+func (in *Interpreter) allowed(importPath string) bool {
+	if in.opts.AllowedPackages == nil {
+		return true
+	}
+	for _, p := range in.opts.AllowedPackages {
+		if p == importPath {
+			return true
+		}
+	}
+	return false
+}
+
+// bindPackages binds the allowed packages registered since the last
+// evaluation under their names. Packages of this interpreter take precedence
+// over global ones. Packages without a name can't be referred to.
+func (in *Interpreter) bindPackages() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	paths := RegisteredPackages()
+	for p := range in.packages {
+		paths = append(paths, p)
+	}
+	for _, p := range paths {
+		if in.bound[p] || !in.allowed(p) {
+			continue
+		}
+		pkg, ok := LookupPackage(p)
+		if local, isLocal := in.packages[p]; isLocal {
+			if !ok {
+				pkg = local.lookup(p)
+			} else {
+				if local.name != "" {
+					pkg.Name = local.name
+				}
+				for name, v := range local.symbols {
+					pkg.Functions[name] = v
+					pkg.Vars[name] = local.vars[name]
+				}
+			}
+		}
+		if p == "fmt" && in.opts.Stdout != nil {
+			in.redirectFmt(pkg)
+		}
+		in.bound[p] = true
+		if pkg.Name == "" {
+			continue
+		}
+		in.scope.Lock()
+		in.scope.Vals[pkg.Name] = pkg
+		in.scope.Unlock()
+	}
+}
+
+// redirectFmt makes the print functions of the fmt package write to Stdout.
+func (in *Interpreter) redirectFmt(pkg Package) {
+	w := in.opts.Stdout
+	pkg.Functions["Print"] = func(a ...interface{}) (int, error) { return fmt.Fprint(w, a...) }
+	pkg.Functions["Printf"] = func(format string, a ...interface{}) (int, error) { return fmt.Fprintf(w, format, a...) }
+	pkg.Functions["Println"] = func(a ...interface{}) (int, error) { return fmt.Fprintln(w, a...) }
+}
+
+// Eval evaluates src in the environment of the interpreter. Variables defined
+// by src are kept for later evaluations. Evaluations are serialized.
+func (in *Interpreter) Eval(ctx context.Context, src string) Result {
+	in.bindPackages()
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	before := map[string]interface{}{}
+	in.scope.Lock()
+	for name, v := range in.scope.Vals {
+		before[name] = v
+	}
+	in.scope.Unlock()
+
+	start := time.Now()
+	v, err := in.scope.EvalContext(ctx, src)
+	res := Result{Value: v, Err: err, Duration: time.Since(start)}
+	if err != nil {
+		res.Value = nil
+	} else if v != nil {
+		res.Type = reflect.TypeOf(v)
+	}
+
+	in.scope.Lock()
+	for name, v := range in.scope.Vals {
+		if old, ok := before[name]; !ok || !sameStorage(old, v) {
+			res.Defined = append(res.Defined, name)
+		}
+	}
+	in.scope.Unlock()
+	sort.Strings(res.Defined)
+	return res
+}
+
+// sameStorage reports whether two values stored in Vals are the same
+// variable.
+func sameStorage(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Ptr && vb.Kind() == reflect.Ptr {
+		return va.Pointer() == vb.Pointer()
+	}
+	return va.Kind() != reflect.Ptr && vb.Kind() != reflect.Ptr
+}
@@ -0,0 +1,136 @@
+package pry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type embedPoint struct {
+	X, Y int
+}
+
+func init() {
+	RegisterPackage("github.com/d4l3k/go-pry/pry/embedtest", "embedtest", map[string]interface{}{
+		"Double": func(n int) int { return n * 2 },
+	})
+	RegisterType("github.com/d4l3k/go-pry/pry/embedtest", "Point", (*embedPoint)(nil))
+	RegisterValue("github.com/d4l3k/go-pry/pry/embedtest", "Answer", 42)
+	RegisterPackage("fmt", "fmt", map[string]interface{}{
+		"Println":  fmt.Println,
+		"Sprintf":  fmt.Sprintf,
+		"Stringer": reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+	})
+}
+
+func TestInterpreterEval(t *testing.T) {
+	t.Parallel()
+
+	in := NewInterpreter(Options{})
+	in.RegisterValue("base", 10)
+	res := in.Eval(context.Background(), `n := embedtest.Double(base) + embedtest.Answer; n`)
+	if res.Err != nil {
+		t.Fatalf("%+v", res.Err)
+	}
+	expected := Result{Value: 62, Type: reflect.TypeOf(0), Defined: []string{"n"}, Duration: res.Duration}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected %#v got %#v.", expected, res)
+	}
+
+	res = in.Eval(context.Background(), `n * 2`)
+	if res.Err != nil || res.Value != 124 || res.Defined != nil {
+		t.Errorf("Expected 124 got %#v.", res)
+	}
+}
+
+func TestInterpreterRegisterType(t *testing.T) {
+	t.Parallel()
+
+	in := NewInterpreter(Options{})
+	in.RegisterType("P", (*embedPoint)(nil))
+	res := in.Eval(context.Background(), `P{X: 1, Y: 2}`)
+	if res.Err != nil {
+		t.Fatalf("%+v", res.Err)
+	}
+	if expected := (embedPoint{1, 2}); res.Value != expected {
+		t.Errorf("Expected %#v got %#v.", expected, res.Value)
+	}
+	res = in.Eval(context.Background(), `embedtest.Point{X: 3}`)
+	if expected := (embedPoint{X: 3}); res.Err != nil || res.Value != expected {
+		t.Errorf("Expected %#v got %#v.", expected, res)
+	}
+}
+
+func TestInterpreterAllowedPackages(t *testing.T) {
+	t.Parallel()
+
+	in := NewInterpreter(Options{AllowedPackages: []string{"fmt"}})
+	if res := in.Eval(context.Background(), `embedtest.Answer`); res.Err == nil {
+		t.Errorf("Expected embedtest to be unavailable got %#v.", res.Value)
+	}
+	if res := in.Eval(context.Background(), `fmt.Sprintf("%d", 1)`); res.Err != nil || res.Value != "1" {
+		t.Errorf("Expected \"1\" got %#v.", res)
+	}
+	in.RegisterPackage("local/pkg", "pkg", map[string]interface{}{"V": 1})
+	if res := in.Eval(context.Background(), `pkg.V`); res.Err == nil {
+		t.Errorf("Expected local/pkg to be unavailable got %#v.", res.Value)
+	}
+}
+
+func TestInterpreterOutput(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	in := NewInterpreter(Options{Stdout: &stdout, Stderr: &stderr})
+	res := in.Eval(context.Background(), `fmt.Println("out", 1); println("err")`)
+	if res.Err != nil {
+		t.Fatalf("%+v", res.Err)
+	}
+	if stdout.String() != "out 1\n" {
+		t.Errorf("Expected %q got %q.", "out 1\n", stdout.String())
+	}
+	if stderr.String() != "err\n" {
+		t.Errorf("Expected %q got %q.", "err\n", stderr.String())
+	}
+}
+
+func TestInterpreterLimits(t *testing.T) {
+	t.Parallel()
+
+	in := NewInterpreter(Options{Limits: Limits{MaxSteps: 10}})
+	res := in.Eval(context.Background(), `for {}`)
+	if err, ok := res.Err.(*LimitError); !ok || err.Kind != StepLimit {
+		t.Errorf("Expected a step limit error got %#v.", res.Err)
+	}
+	if res.Value != nil || !strings.Contains(fmt.Sprint(res.Err), "step limit") {
+		t.Errorf("Unexpected result %#v.", res)
+	}
+}
+
+func TestInterpreterPackageName(t *testing.T) {
+	t.Parallel()
+
+	in := NewInterpreter(Options{})
+	in.RegisterPackage("github.com/influxdata/influxdb1-client/v2", "client", map[string]interface{}{"V": 1})
+	if res := in.Eval(context.Background(), `client.V`); res.Err != nil || res.Value != 1 {
+		t.Errorf("Expected 1 got %#v.", res)
+	}
+	if res := in.Eval(context.Background(), `v2.V`); res.Err == nil {
+		t.Errorf("Expected the package not to be bound as v2 got %#v.", res.Value)
+	}
+
+	scope := NewScope()
+	RegisterValue("github.com/d4l3k/go-pry/pry/unnamedtest", "V", 3)
+	if _, err := scope.Import(context.Background(), "github.com/d4l3k/go-pry/pry/unnamedtest", ""); err == nil {
+		t.Errorf("Expected importing a package without a name to fail.")
+	}
+	if _, err := scope.Import(context.Background(), "github.com/d4l3k/go-pry/pry/unnamedtest", "u"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if out, err := scope.InterpretString(`u.V`); err != nil || out != 3 {
+		t.Errorf("Expected 3 got %#v, %v.", out, err)
+	}
+}
@@ -0,0 +1,96 @@
+package pry
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Engine evaluates a line of REPL input against a scope. Engines stop with
+// ErrInterrupted once ctx is done.
+type Engine interface {
+	Eval(ctx context.Context, scope *Scope, src string) (interface{}, error)
+}
+
+// ReflectEngine is the default engine. It compiles the input to closures when
+// possible and otherwise walks the AST using reflection.
+type ReflectEngine struct{}
+
+// Eval implements Engine.
+func (ReflectEngine) Eval(ctx context.Context, scope *Scope, src string) (interface{}, error) {
+	return scope.InterpretStringContext(ctx, src)
+}
+
+var (
+	enginesMu sync.Mutex
+	engines   = map[string]Engine{
+		"reflect": ReflectEngine{},
+		"ssa":     SSAEngine{},
+	}
+)
+
+// RegisterEngine makes an engine selectable by name from the REPL.
+func RegisterEngine(name string, engine Engine) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	engines[name] = engine
+}
+
+// LookupEngine returns the engine registered under name.
+func LookupEngine(name string) (Engine, error) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	engine, ok := engines[name]
+	if !ok {
+		return nil, errors.Errorf("unknown engine %q", name)
+	}
+	return engine, nil
+}
+
+// EngineNames returns the names of all registered engines.
+func EngineNames() []string {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	var names []string
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// engineName returns the name engine is registered under.
+func engineName(engine Engine) string {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	for name, e := range engines {
+		if e == engine {
+			return name
+		}
+	}
+	return "custom"
+}
+
+// engine returns the engine used to evaluate input in scope. Child scopes use
+// the engine of their parent.
+func (scope *Scope) engine() Engine {
+	for s := scope; s != nil; s = s.Parent {
+		if s.Engine != nil {
+			return s.Engine
+		}
+	}
+	return ReflectEngine{}
+}
+
+// Eval evaluates src with the engine of the scope.
+func (scope *Scope) Eval(src string) (interface{}, error) {
+	return scope.EvalContext(context.Background(), src)
+}
+
+// EvalContext evaluates src with the engine of the scope, stopping with
+// ErrInterrupted once ctx is done.
+func (scope *Scope) EvalContext(ctx context.Context, src string) (interface{}, error) {
+	return scope.engine().Eval(ctx, scope, src)
+}
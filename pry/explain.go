@@ -0,0 +1,202 @@
+package pry
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExplainedValue is the value of a sub-expression recorded by Explain.
+type ExplainedValue struct {
+	// Expr is the source of the sub-expression.
+	Expr string
+	// Offset is the byte offset in the source the value is shown under: the
+	// operator of binary and unary expressions, the bracket of index
+	// expressions, the selected name of selectors and calls, and the start of
+	// identifiers.
+	Offset int
+	Value  interface{}
+}
+
+// Explanation is the trace of an evaluation recorded by Explain.
+type Explanation struct {
+	Source string
+	Result interface{}
+	// Values holds the last value of each sub-expression, ordered by Offset.
+	Values []ExplainedValue
+}
+
+// explainer records the values of sub-expressions during an evaluation.
+type explainer struct {
+	values map[ast.Node]interface{}
+	order  []ast.Node
+}
+
+func (e *explainer) record(node ast.Node, v interface{}) {
+	if _, ok := e.values[node]; !ok {
+		e.order = append(e.order, node)
+	}
+	e.values[node] = v
+}
+
+// explaining returns the explainer of the evaluation running in scope.
+func (scope *Scope) explaining() *explainer {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		e := s.explain
+		s.Unlock()
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// explainable reports whether the value of node is shown by Explain.
+func explainable(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.BinaryExpr, *ast.UnaryExpr, *ast.CallExpr, *ast.SelectorExpr, *ast.IndexExpr, *ast.Ident:
+		return true
+	}
+	return false
+}
+
+// explainAnchor returns the position the value of node is shown under.
+func explainAnchor(node ast.Node) token.Pos {
+	switch n := node.(type) {
+	case *ast.BinaryExpr:
+		return n.OpPos
+	case *ast.UnaryExpr:
+		return n.OpPos
+	case *ast.IndexExpr:
+		return n.Lbrack
+	case *ast.SelectorExpr:
+		return n.Sel.Pos()
+	case *ast.CallExpr:
+		if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+			return sel.Sel.Pos()
+		}
+		return n.Fun.Pos()
+	}
+	return node.Pos()
+}
+
+// explainedValue reports whether v is worth showing. Types, packages and
+// functions are left out.
+func explainedValue(v interface{}) bool {
+	switch v.(type) {
+	case reflect.Type, Package, *Package, *Func:
+		return false
+	}
+	return v == nil || reflect.TypeOf(v).Kind() != reflect.Func
+}
+
+// Explain evaluates src like InterpretString and records the value of every
+// sub-expression, so it can be shown why an expression has the value it has.
+// The input is always walked rather than compiled.
+func (scope *Scope) Explain(src string) (*Explanation, error) {
+	return scope.ExplainContext(context.Background(), src)
+}
+
+// ExplainContext is Explain, stopping with ErrInterrupted once ctx is done.
+func (scope *Scope) ExplainContext(ctx context.Context, src string) (ex *Explanation, err error) {
+	_, end := scope.beginEval(ctx)
+	defer end()
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("interpreting %q: %s", src, fmt.Sprint(r))
+		}
+	}()
+
+	node, shifted, err := scope.ParseString(src)
+	if err != nil {
+		return nil, err
+	}
+	if errs := scope.CheckStatement(node); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	e := &explainer{values: map[ast.Node]interface{}{}}
+	scope.Lock()
+	prev := scope.explain
+	scope.explain = e
+	scope.Unlock()
+	defer func() {
+		scope.Lock()
+		scope.explain = prev
+		scope.Unlock()
+	}()
+
+	input := &source{src: strings.Trim(src, " \n\t"), shifted: shifted}
+	defer scope.withSource(input)()
+	result, err := scope.Interpret(node)
+	if err != nil {
+		return nil, err
+	}
+
+	ex = &Explanation{Source: input.src, Result: result}
+	byOffset := map[int]int{}
+	for _, n := range e.order {
+		v := e.values[n]
+		start, stop, anchor := input.offset(n.Pos()), input.offset(n.End()), input.offset(explainAnchor(n))
+		if start < 0 || stop < 0 || !explainedValue(v) {
+			continue
+		}
+		val := ExplainedValue{Expr: ex.Source[start:stop], Offset: anchor, Value: v}
+		// Outer expressions are recorded last and win shared anchors.
+		if i, ok := byOffset[anchor]; ok {
+			ex.Values[i] = val
+			continue
+		}
+		byOffset[anchor] = len(ex.Values)
+		ex.Values = append(ex.Values, val)
+	}
+	sort.Slice(ex.Values, func(i, j int) bool { return ex.Values[i].Offset < ex.Values[j].Offset })
+	return ex, nil
+}
+
+// String renders the explanation as a diagram with the value of each
+// sub-expression hanging under the source line.
+//
+//	a[1] == b
+//	 |   |  |
+//	 |   |  3
+//	 |   false
+//	 2
+func (ex *Explanation) String() string {
+	var b strings.Builder
+	b.WriteString(ex.Source)
+	b.WriteString("\n")
+	if len(ex.Values) == 0 {
+		return b.String()
+	}
+	pipes := func(n int) string {
+		line := []byte(strings.Repeat(" ", ex.Values[n-1].Offset+1))
+		for _, v := range ex.Values[:n] {
+			line[v.Offset] = '|'
+		}
+		return string(line)
+	}
+	b.WriteString(pipes(len(ex.Values)))
+	b.WriteString("\n")
+	for i := len(ex.Values) - 1; i >= 0; i-- {
+		v := ex.Values[i]
+		line := ""
+		if i > 0 {
+			line = pipes(i)
+		}
+		if pad := v.Offset - len(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		line = line[:v.Offset] + fmt.Sprintf("%#v", v.Value)
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,19 @@
+//go:build go1.18
+// +build go1.18
+
+package pry
+
+import "go/types"
+
+// isGeneric reports whether obj is a generic function or type, which can't be
+// referenced without instantiating it.
+func isGeneric(obj types.Object) bool {
+	switch obj := obj.(type) {
+	case *types.Func:
+		return obj.Type().(*types.Signature).TypeParams().Len() > 0
+	case *types.TypeName:
+		named, ok := obj.Type().(*types.Named)
+		return ok && named.TypeParams().Len() > 0
+	}
+	return false
+}
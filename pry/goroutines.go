@@ -0,0 +1,244 @@
+package pry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// GoroutineState is the state of a goroutine started by interpreted code.
+type GoroutineState int
+
+// The states of a goroutine.
+const (
+	GoroutineRunning GoroutineState = iota
+	GoroutineFinished
+	GoroutineFailed
+	GoroutineCancelled
+)
+
+func (s GoroutineState) String() string {
+	switch s {
+	case GoroutineRunning:
+		return "running"
+	case GoroutineFinished:
+		return "finished"
+	case GoroutineFailed:
+		return "failed"
+	case GoroutineCancelled:
+		return "cancelled"
+	}
+	return fmt.Sprintf("GoroutineState(%d)", int(s))
+}
+
+// Goroutine is a goroutine started by a go statement in interpreted code.
+type Goroutine struct {
+	ID int
+	// Source is the go statement that started the goroutine.
+	Source  string
+	Started time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	state    GoroutineState
+	err      error
+	reported bool
+}
+
+// State returns the current state of the goroutine.
+func (g *Goroutine) State() GoroutineState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state
+}
+
+// Err returns the error the goroutine failed with, if any.
+func (g *Goroutine) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// Done returns a channel that's closed once the goroutine exits.
+func (g *Goroutine) Done() <-chan struct{} {
+	return g.done
+}
+
+// Cancel asks the goroutine to stop at its next loop iteration, call or
+// channel operation.
+func (g *Goroutine) Cancel() {
+	g.cancel()
+}
+
+// Wait waits for the goroutine to exit and returns its error. It returns
+// ErrInterrupted if ctx is done first.
+func (g *Goroutine) Wait(ctx context.Context) error {
+	select {
+	case <-g.done:
+		return g.Err()
+	case <-ctx.Done():
+		return ErrInterrupted
+	}
+}
+
+func (g *Goroutine) finish(err error) {
+	g.mu.Lock()
+	switch {
+	case err == nil:
+		g.state = GoroutineFinished
+	case errors.Cause(err) == ErrInterrupted && g.ctx.Err() != nil:
+		g.state = GoroutineCancelled
+	default:
+		g.state = GoroutineFailed
+		g.err = err
+	}
+	g.mu.Unlock()
+	g.cancel()
+	close(g.done)
+}
+
+// maxExitedGoroutines is how many exited goroutines a registry keeps, so
+// interpreters embedded in long running processes don't keep a record of every
+// go statement they ran.
+const maxExitedGoroutines = 100
+
+// goroutineRegistry holds the goroutines started from a scope tree. Running
+// goroutines are kept until they exit, and only the last maxExitedGoroutines
+// exited ones after that.
+type goroutineRegistry struct {
+	mu         sync.Mutex
+	nextID     int
+	goroutines []*Goroutine
+}
+
+// registry returns the goroutine registry of the root of the scope tree.
+func (scope *Scope) registry() *goroutineRegistry {
+	root := scope
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	root.Lock()
+	defer root.Unlock()
+	if root.goroutines == nil {
+		root.goroutines = &goroutineRegistry{}
+	}
+	return root.goroutines
+}
+
+// Goroutines returns the goroutines started by interpreted code, ordered by
+// ID. Only the last maxExitedGoroutines of those that exited are kept.
+func (scope *Scope) Goroutines() []*Goroutine {
+	r := scope.registry()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Goroutine(nil), r.goroutines...)
+}
+
+// Goroutine returns the goroutine with the given ID.
+func (scope *Scope) Goroutine(id int) (*Goroutine, bool) {
+	for _, g := range scope.Goroutines() {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// failedGoroutines returns the goroutines that failed since it was last
+// called.
+func (scope *Scope) failedGoroutines() []*Goroutine {
+	var failed []*Goroutine
+	for _, g := range scope.Goroutines() {
+		g.mu.Lock()
+		if g.state == GoroutineFailed && !g.reported {
+			g.reported = true
+			failed = append(failed, g)
+		}
+		g.mu.Unlock()
+	}
+	return failed
+}
+
+// spawn runs fn in a new goroutine registered under source. fn is passed a
+// context that's cancelled by Goroutine.Cancel. It outlives the evaluation
+// that started it.
+func (scope *Scope) spawn(source string, fn func(ctx context.Context) error) error {
+	release, err := scope.limits().startGoroutine()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &Goroutine{
+		Source:  source,
+		Started: time.Now(),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	r := scope.registry()
+	r.mu.Lock()
+	r.nextID++
+	g.ID = r.nextID
+	r.goroutines = append(r.goroutines, g)
+	r.prune()
+	r.mu.Unlock()
+
+	go func() {
+		defer release()
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = panicError(r)
+			}
+			g.finish(err)
+		}()
+		err = fn(ctx)
+	}()
+	return nil
+}
+
+// prune drops the oldest exited goroutines beyond maxExitedGoroutines. r.mu
+// must be held.
+func (r *goroutineRegistry) prune() {
+	exited := 0
+	for _, g := range r.goroutines {
+		if g.State() != GoroutineRunning {
+			exited++
+		}
+	}
+	if exited <= maxExitedGoroutines {
+		return
+	}
+	kept := r.goroutines[:0]
+	for _, g := range r.goroutines {
+		if exited > maxExitedGoroutines && g.State() != GoroutineRunning {
+			exited--
+			continue
+		}
+		kept = append(kept, g)
+	}
+	for i := len(kept); i < len(r.goroutines); i++ {
+		r.goroutines[i] = nil
+	}
+	r.goroutines = kept
+}
+
+// goSource renders a go statement on a single line.
+func goSource(stmt *ast.GoStmt) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), stmt); err != nil {
+		return "go statement"
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
@@ -50,9 +50,9 @@ func Make(t interface{}, args ...interface{}) (interface{}, *InterpretError) {
 		}
 		capacity := length
 		if len(args) == 2 {
-			capacity, isInt = args[0].(int)
+			capacity, isInt = args[1].(int)
 			if !isInt {
-				return nil, &InterpretError{errors.New("len is not int")}
+				return nil, &InterpretError{errors.New("cap is not int")}
 			}
 		}
 		if length < 0 || capacity < 0 {
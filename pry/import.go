@@ -0,0 +1,113 @@
+package pry
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Import makes the package with the import path available to the input as
+// name, or as the name of the package if name is empty. Packages that aren't
+// registered are built into a plugin exporting their symbol table, which is
+// loaded into the process. Plugins of packages with a module version are
+// cached, so importing them again is fast.
+func (scope *Scope) Import(ctx context.Context, path, name string) (Package, error) {
+	pkg, ok := LookupPackage(path)
+	if !ok {
+		if err := loadPlugin(ctx, scope.sourceDir(), path); err != nil {
+			return Package{}, errors.Wrapf(err, "importing %q", path)
+		}
+		if pkg, ok = LookupPackage(path); !ok {
+			return Package{}, errors.Errorf("importing %q: the plugin didn't register the package", path)
+		}
+	}
+	switch name {
+	case "":
+		if pkg.Name == "" {
+			return Package{}, errors.Errorf("importing %q: the package has no name, import it under one", path)
+		}
+		name = pkg.Name
+	case "_":
+		return pkg, nil
+	case ".":
+		return Package{}, errors.New("dot imports are not supported")
+	}
+	if err := scope.addImport(path, name); err != nil {
+		return Package{}, err
+	}
+	scope.Lock()
+	scope.bind(name, pkg)
+	scope.Unlock()
+	return pkg, nil
+}
+
+// sourceDir returns the directory of the program the scope was captured
+// from, or the working directory.
+func (scope *Scope) sourceDir() string {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		path := s.path
+		s.Unlock()
+		if path != "" {
+			return filepath.Dir(path)
+		}
+	}
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// addImport adds the import to the file the scope was type checked against,
+// so input using the package type checks too.
+func (scope *Scope) addImport(path, name string) error {
+	if scope.typeInfo() == nil {
+		return nil
+	}
+	file := scope.pryFile()
+	if file == nil {
+		return nil
+	}
+	for _, imp := range file.Imports {
+		if imp.Path.Value == strconv.Quote(path) && (imp.Name == nil || imp.Name.Name == name) {
+			return nil
+		}
+	}
+	spec := &ast.ImportSpec{
+		Name: ast.NewIdent(name),
+		Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)},
+	}
+	decl := &ast.GenDecl{Tok: token.IMPORT, Specs: []ast.Spec{spec}}
+	oldDecls, oldImports := file.Decls, file.Imports
+	file.Decls = append([]ast.Decl{decl}, file.Decls...)
+	file.Imports = append(file.Imports, spec)
+	if _, errs := scope.TypeCheck(); len(errs) > 0 {
+		file.Decls, file.Imports = oldDecls, oldImports
+		return errs[0]
+	}
+	return nil
+}
+
+// parseImports parses an import declaration typed at the prompt and returns
+// its specs.
+func parseImports(line string) ([]*ast.ImportSpec, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p;"+line, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Imports) == 0 {
+		return nil, errors.New("expected an import declaration")
+	}
+	return f.Imports, nil
+}
+
+// isImport reports whether line is an import declaration.
+func isImport(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) > 0 && (fields[0] == "import" || strings.HasPrefix(fields[0], "import("))
+}
@@ -0,0 +1,96 @@
+package pry
+
+import (
+	"context"
+	"html/template"
+	"reflect"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestParseImports(t *testing.T) {
+	t.Parallel()
+
+	for line, expected := range map[string][]string{
+		`import "net/url"`:              {"", `"net/url"`},
+		`import u "net/url"`:            {"u", `"net/url"`},
+		`import ("fmt"; s "strings")`:   {"", `"fmt"`, "s", `"strings"`},
+		`import("fmt")`:                 {"", `"fmt"`},
+		`importantValue := 1`:           nil,
+		`fmt.Println("import \"fmt\"")`: nil,
+	} {
+		if !isImport(line) {
+			if expected != nil {
+				t.Errorf("%s: Expected an import.", line)
+			}
+			continue
+		}
+		specs, err := parseImports(line)
+		if err != nil {
+			t.Errorf("%s: %v", line, err)
+			continue
+		}
+		var out []string
+		for _, spec := range specs {
+			name := ""
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			out = append(out, name, spec.Path.Value)
+		}
+		if !reflect.DeepEqual(expected, out) {
+			t.Errorf("%s: Expected %#v got %#v.", line, expected, out)
+		}
+	}
+}
+
+func TestImportRegistered(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	if _, err := scope.Import(context.Background(), "github.com/d4l3k/go-pry/pry/embedtest", "et"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	out, err := scope.InterpretString(`et.Double(et.Answer)`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 84
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+	if _, err := scope.Import(context.Background(), "fmt", "."); err == nil {
+		t.Errorf("Expected dot imports to fail.")
+	}
+}
+
+func TestImportSameName(t *testing.T) {
+	t.Parallel()
+
+	RegisterPackage("text/template", "template", map[string]interface{}{
+		"New":      texttemplate.New,
+		"Template": Type((*texttemplate.Template)(nil)).Elem(),
+	})
+	RegisterPackage("html/template", "template", map[string]interface{}{
+		"New":      template.New,
+		"Template": Type((*template.Template)(nil)).Elem(),
+	})
+	scope := testTypedScope(t)
+	ctx := context.Background()
+	if _, err := scope.Import(ctx, "text/template", ""); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := scope.Import(ctx, "html/template", "htmltemplate"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	out, err := scope.InterpretString(`
+		t := htmltemplate.New("page")
+		t
+	`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, ok := out.(*template.Template); !ok {
+		t.Errorf("Expected a *html/template.Template got %T.", out)
+	}
+}
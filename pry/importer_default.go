@@ -4,6 +4,8 @@ package pry
 
 import (
 	"go/ast"
+	"go/importer"
+	"go/token"
 	"go/types"
 	"path/filepath"
 
@@ -27,6 +29,16 @@ func (packagesImporter) ImportFrom(path, dir string, mode types.ImportMode) (*ty
 		return nil, errors.Wrapf(err, "importing %q", path)
 	}
 	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, errors.Wrapf(pkg.Errors[0], "importing %q", path)
+	}
+	if pkg.IllTyped {
+		// The export data of the go command isn't always readable by
+		// go/packages, in which case the package is type checked from
+		// source.
+		imp := importer.ForCompiler(token.NewFileSet(), "source", nil).(types.ImporterFrom)
+		return imp.ImportFrom(path, dir, mode)
+	}
 	return pkg.Types, nil
 }
 
@@ -2,6 +2,7 @@ package pry
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -29,22 +30,44 @@ var (
 	ErrBranchBreak = errors.New("branch break")
 	// ErrBranchContinue is an internal error thrown when a for loop continues.
 	ErrBranchContinue = errors.New("branch continue")
+	// ErrBranchReturn is an internal error thrown when a function returns.
+	ErrBranchReturn = errors.New("branch return")
 )
 
 // Scope is a string-interface key-value pair that represents variables/functions in scope.
 type Scope struct {
+	// Vals maps names to their values. Variables defined by the interpreter
+	// are stored as pointers to their storage, which Get dereferences, so
+	// closures and assignments share it. go-pry captures the variables of the
+	// program the same way, so assignments at the prompt change them. Prefer
+	// Get, Set, Delete and Walk to accessing it directly.
 	Vals   map[string]interface{}
 	Parent *Scope
 	Files  map[string]*ast.File
-	config *types.Config
-	path   string
-	line   int
-	fset   *token.FileSet
+	// Engine evaluates REPL input. The ReflectEngine is used when it's nil.
+	Engine Engine
+	ctx    context.Context
+	budget *budget
+	// goroutines is only set on the root scope.
+	goroutines *goroutineRegistry
+	explain    *explainer
+	trace      *tracer
+	source     *source
+	// captured holds the names of Vals captured from the program.
+	captured map[string]bool
+	config   *types.Config
+	info     *types.Info
+	pkg      *types.Package
+	path     string
+	line     int
+	fset     *token.FileSet
 
 	isSelect   bool
 	typeAssert reflect.Type
 	isFunction bool
 	defers     []*Defer
+	// ret holds the results of the return statement of a function scope.
+	ret interface{}
 
 	sync.Mutex
 }
@@ -56,13 +79,22 @@ type Defer struct {
 }
 
 func (scope *Scope) Defer(d *Defer) error {
+	if fn := scope.functionScope(); fn != nil {
+		fn.defers = append(fn.defers, d)
+		return nil
+	}
+	return errors.New("defer: can't find function scope")
+}
+
+// functionScope returns the scope of the innermost function being called, nil
+// outside of functions.
+func (scope *Scope) functionScope() *Scope {
 	for ; scope != nil; scope = scope.Parent {
 		if scope.isFunction {
-			scope.defers = append(scope.defers, d)
-			return nil
+			return scope
 		}
 	}
-	return errors.New("defer: can't find function scope")
+	return nil
 }
 
 // NewScope creates a new initialized scope
@@ -90,7 +122,7 @@ func (scope *Scope) GetPointer(name string) (val interface{}, exists bool) {
 // Get walks the scope and finds the value of interest
 func (scope *Scope) Get(name string) (interface{}, bool) {
 	val, exists := scope.GetPointer(name)
-	if !exists || val == nil {
+	if !exists || val == nil || isType(val) {
 		return val, exists
 	}
 	v := reflect.ValueOf(val)
@@ -100,10 +132,20 @@ func (scope *Scope) Get(name string) (interface{}, bool) {
 	return v.Interface(), exists
 }
 
-// Set walks the scope and sets a value in a parent scope if it exists, else current.
+// isType reports whether the value stored in a scope is a type. Types are
+// stored as their reflect.Type, which is a pointer but not to a variable.
+func isType(stored interface{}) bool {
+	_, ok := stored.(reflect.Type)
+	return ok
+}
+
+// Set walks the scope and sets a value in a parent scope if it exists, else
+// current. Values assignable to the existing variable are stored in place so
+// pointers to the variable observe the change.
 func (scope *Scope) Set(name string, val interface{}) {
+	var value reflect.Value
 	if val != nil {
-		value := reflect.ValueOf(val)
+		value = reflect.ValueOf(val)
 		if !value.CanAddr() {
 			nv := reflect.New(value.Type())
 			nv.Elem().Set(value)
@@ -117,9 +159,15 @@ func (scope *Scope) Set(name string, val interface{}) {
 	currentScope := scope
 	for !exists && currentScope != nil {
 		currentScope.Lock()
-		_, exists = currentScope.Vals[name]
+		var old interface{}
+		old, exists = currentScope.Vals[name]
 		if exists {
-			currentScope.Vals[name] = val
+			if oldV := reflect.ValueOf(old); value.IsValid() && oldV.Kind() == reflect.Ptr &&
+				!oldV.IsNil() && value.Type().AssignableTo(oldV.Elem().Type()) {
+				oldV.Elem().Set(value)
+			} else {
+				currentScope.Vals[name] = val
+			}
 		}
 		currentScope.Unlock()
 		currentScope = currentScope.Parent
@@ -131,20 +179,6 @@ func (scope *Scope) Set(name string, val interface{}) {
 	}
 }
 
-// Keys returns all keys in scope
-func (scope *Scope) Keys() (keys []string) {
-	currentScope := scope
-	for currentScope != nil {
-		currentScope.Lock()
-		for k := range currentScope.Vals {
-			keys = append(keys, k)
-		}
-		currentScope.Unlock()
-		currentScope = scope.Parent
-	}
-	return
-}
-
 // NewChild creates a scope under the existing scope.
 func (scope *Scope) NewChild() *Scope {
 	s := NewScope()
@@ -155,6 +189,18 @@ func (scope *Scope) NewChild() *Scope {
 // Func represents an interpreted function definition.
 type Func struct {
 	Def *ast.FuncLit
+
+	// source is the input the function was defined in.
+	source *source
+}
+
+func (f *Func) String() string {
+	if f.source != nil {
+		if text := f.source.text(f.Def.Type); text != "" {
+			return text + " {...}"
+		}
+	}
+	return "func {...}"
 }
 
 // ParseString parses go code into the ast nodes.
@@ -189,13 +235,22 @@ func (scope *Scope) ParseString(exprStr string) (ast.Node, int, error) {
 
 // InterpretString interprets a string of go code and returns the result.
 func (scope *Scope) InterpretString(exprStr string) (v interface{}, err error) {
+	return scope.InterpretStringContext(context.Background(), exprStr)
+}
+
+// InterpretStringContext is InterpretString that stops with ErrInterrupted
+// once ctx is done. Cancellation and the limits of the scope are checked at
+// loop iterations, channel operations and calls.
+func (scope *Scope) InterpretStringContext(ctx context.Context, exprStr string) (v interface{}, err error) {
+	_, end := scope.beginEval(ctx)
+	defer end()
 	defer func() {
 		if r := recover(); r != nil {
 			err = errors.Errorf("interpreting %q: %s", exprStr, fmt.Sprint(r))
 		}
 	}()
 
-	node, _, err := scope.ParseString(exprStr)
+	node, shifted, err := scope.ParseString(exprStr)
 	if err != nil {
 		return node, err
 	}
@@ -203,17 +258,60 @@ func (scope *Scope) InterpretString(exprStr string) (v interface{}, err error) {
 	if len(errs) > 0 {
 		return node, errs[0]
 	}
+	if scope.tracing() == nil {
+		if prog, err := scope.Compile(node); err == nil {
+			return prog.Run()
+		}
+	}
+	defer scope.withSource(&source{src: strings.Trim(exprStr, " \n\t"), shifted: shifted})()
 	return scope.Interpret(node)
 }
 
-// Interpret interprets an ast.Node and returns the value.
+// Interpret interprets an ast.Node and returns the value. When the scope has
+// been type checked, expressions are evaluated with their static type.
 func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
+	var t *tracer
+	if traced(expr) {
+		if t = scope.tracing(); t != nil {
+			scope.traceStmt(t, expr.(ast.Stmt))
+		}
+	} else if _, ok := expr.(*ast.CaseClause); ok {
+		if t := scope.tracing(); t != nil {
+			scope.traceClause(t, expr)
+		}
+	} else if _, ok := expr.(*ast.CommClause); ok {
+		if t := scope.tracing(); t != nil {
+			scope.traceClause(t, expr)
+		}
+	}
+	v, err := scope.interpretNode(expr)
+	if err == nil && t != nil {
+		scope.traceAssigned(t, expr.(ast.Stmt))
+	}
+	if err == nil && explainable(expr) {
+		if e := scope.explaining(); e != nil {
+			e.record(expr, v)
+		}
+	}
+	return v, err
+}
+
+func (scope *Scope) interpretNode(expr ast.Node) (interface{}, error) {
+	if e, ok := expr.(ast.Expr); ok {
+		if v, typed, err := scope.interpretTyped(e); typed || err != nil {
+			return v, err
+		}
+	}
+	return scope.interpret(expr)
+}
+
+func (scope *Scope) interpret(expr ast.Node) (interface{}, error) {
 	builtinScope := map[string]interface{}{
 		"nil":    nil,
 		"true":   true,
 		"false":  false,
-		"append": Append,
-		"make":   Make,
+		"append": scope.limitedAppend,
+		"make":   scope.limitedMake,
 		"len":    Len,
 		"close":  Close,
 	}
@@ -241,32 +339,11 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		sel := e.Sel
-
 		rVal := reflect.ValueOf(X)
-		if rVal.Kind() != reflect.Struct && rVal.Kind() != reflect.Ptr {
-			return nil, fmt.Errorf("%#v is not a struct and thus has no field %#v", X, sel.Name)
-		}
-
-		pkg, isPackage := X.(Package)
-		if isPackage {
-			obj, isPresent := pkg.Functions[sel.Name]
-			if isPresent {
-				return obj, nil
-			}
-			return nil, fmt.Errorf("unknown field %#v", sel.Name)
-		}
-
-		if method := rVal.MethodByName(sel.Name); method.IsValid() {
-			return method.Interface(), nil
-		}
-		if rVal.Kind() == reflect.Ptr {
-			rVal = rVal.Elem()
+		if rVal.Kind() == reflect.Struct {
+			rVal = scope.methodReceiver(e, rVal)
 		}
-		if field := rVal.FieldByName(sel.Name); field.IsValid() {
-			return field.Interface(), nil
-		}
-		return nil, fmt.Errorf("unknown field %#v", sel.Name)
+		return selectMember(rVal, e.Sel.Name)
 
 	case *ast.CallExpr:
 		args := make([]interface{}, len(e.Args))
@@ -281,13 +358,14 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		return scope.ExecuteFunc(e.Fun, args)
 
 	case *ast.GoStmt:
-		go func() {
-			_, err := scope.NewChild().Interpret(e.Call)
-			if err != nil {
-				fmt.Printf("goroutine failed: %s\n", err)
-			}
-		}()
-		return nil, nil
+		source := scope.currentSource()
+		return nil, scope.spawn(goSource(e), func(ctx context.Context) error {
+			child := scope.NewChild()
+			child.ctx = ctx
+			child.source = source
+			_, err := child.Interpret(e.Call)
+			return err
+		})
 
 	case *ast.BasicLit:
 		switch e.Kind {
@@ -326,6 +404,9 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 			case reflect.Slice:
 				slice = reflect.MakeSlice(aType, l, l)
 			case reflect.Array:
+				if err := scope.checkArray(aType); err != nil {
+					return nil, err
+				}
 				slice = reflect.New(aType).Elem()
 			default:
 				return nil, errors.Errorf("unknown array type %#v", typ)
@@ -484,11 +565,11 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		xVal := reflect.ValueOf(X)
-		for xVal.Type().Kind() == reflect.Ptr {
-			xVal = xVal.Elem()
+		xVal, err := derefArray(reflect.ValueOf(X))
+		if err != nil {
+			return nil, err
 		}
-		switch xVal.Type().Kind() {
+		switch xVal.Kind() {
 		case reflect.Map:
 			val := xVal.MapIndex(reflect.ValueOf(i))
 			if !val.IsValid() {
@@ -497,15 +578,14 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 			}
 			return val.Interface(), nil
 
-		case reflect.Slice, reflect.Array:
-			iVal, isInt := i.(int)
-			if !isInt {
-				return nil, fmt.Errorf("index has to be an int not %T", i)
+		case reflect.Slice, reflect.Array, reflect.String:
+			iVal, err := toIndex(i)
+			if err != nil {
+				return nil, err
 			}
-			if iVal >= xVal.Len() || iVal < 0 {
-				return nil, errors.New("slice index out of range")
+			if err := checkIndex(iVal, xVal.Len()); err != nil {
+				return nil, err
 			}
-
 			return xVal.Index(iVal).Interface(), nil
 
 		default:
@@ -513,44 +593,36 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		}
 
 	case *ast.SliceExpr:
-		low, err := scope.Interpret(e.Low)
-		if err != nil {
-			return nil, err
-		}
-		high, err := scope.Interpret(e.High)
-		if err != nil {
-			return nil, err
-		}
-		X, err := scope.Interpret(e.X)
+		xVal, err := scope.sliceOperand(e.X)
 		if err != nil {
 			return nil, err
 		}
-		xVal := reflect.ValueOf(X)
-		if low == nil {
-			low = 0
+		if e.Slice3 && xVal.Kind() == reflect.String {
+			return nil, errors.Errorf("invalid operation: 3-index slice of string")
 		}
-		kind := xVal.Kind()
-		if kind != reflect.Array && kind != reflect.Slice {
-			return nil, errors.Errorf("invalid X for SliceExpr: %#v", X)
+		indexes := []int{0, xVal.Len(), xVal.Len()}
+		if xVal.Kind() != reflect.String {
+			indexes[2] = xVal.Cap()
 		}
-		if high == nil {
-			high = xVal.Len()
-		}
-		lowVal, isLowInt := low.(int)
-		highVal, isHighInt := high.(int)
-		if !isLowInt || !isHighInt {
-			return nil, fmt.Errorf("slice: indexes have to be an ints not %T and %T", low, high)
-		}
-		if lowVal < 0 || highVal >= xVal.Len() || highVal < lowVal {
-			return nil, errors.New("slice: index out of bounds")
+		for j, idx := range []ast.Expr{e.Low, e.High, e.Max} {
+			if idx == nil {
+				continue
+			}
+			v, err := scope.Interpret(idx)
+			if err != nil {
+				return nil, err
+			}
+			if indexes[j], err = toIndex(v); err != nil {
+				return nil, err
+			}
 		}
-		return xVal.Slice(lowVal, highVal).Interface(), nil
+		return sliceValue(xVal, indexes[0], indexes[1], indexes[2], e.Slice3)
 
 	case *ast.ParenExpr:
 		return scope.Interpret(e.X)
 
 	case *ast.FuncLit:
-		return &Func{e}, nil
+		return &Func{Def: e, source: scope.currentSource()}, nil
 	case *ast.BlockStmt:
 		var outFinal interface{}
 		for _, stmts := range e.List {
@@ -572,12 +644,20 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 			results[i] = out
 		}
 
-		if len(results) == 0 {
-			return nil, nil
-		} else if len(results) == 1 {
-			return results[0], nil
+		var ret interface{}
+		if len(results) == 1 {
+			ret = results[0]
+		} else if len(results) > 1 {
+			ret = results
+		}
+		// Returns outside of a function, like at the prompt, just evaluate to
+		// their results.
+		fn := scope.functionScope()
+		if fn == nil {
+			return ret, nil
 		}
-		return results, nil
+		fn.ret = ret
+		return ret, ErrBranchReturn
 
 	case *ast.AssignStmt:
 		// TODO implement type checking
@@ -624,6 +704,9 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 			}
 
 			if ident, ok := id.(*ast.Ident); ok {
+				if ident.Name == "_" {
+					continue
+				}
 				val, exists := scope.Get(ident.Name)
 				if !exists && (e.Tok != token.DEFINE) {
 					return nil, errors.Errorf("undefined %s", ident.Name)
@@ -633,7 +716,11 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 				if err != nil {
 					return nil, err
 				}
-				scope.Set(ident.Name, r)
+				if e.Tok == token.DEFINE {
+					scope.Define(ident, r)
+				} else {
+					scope.Set(ident.Name, r)
+				}
 				continue
 			} else if idx, ok := id.(*ast.IndexExpr); ok {
 				left, err := scope.getValue(idx.X)
@@ -698,41 +785,49 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 				},
 			}},
 		}
-		return scope.Interpret(ass)
+		return scope.interpretNode(ass)
 	case *ast.RangeStmt:
 		s := scope.NewChild()
 		ranger, err := s.Interpret(e.X)
 		if err != nil {
 			return nil, err
 		}
-		var key, value string
+		var key, value *ast.Ident
 		if e.Key != nil {
-			key = e.Key.(*ast.Ident).Name
+			key = e.Key.(*ast.Ident)
 		}
 		if e.Value != nil {
-			value = e.Value.(*ast.Ident).Name
+			value = e.Value.(*ast.Ident)
+		}
+		set := func(ident *ast.Ident, val interface{}) {
+			if ident == nil || ident.Name == "_" {
+				return
+			}
+			if e.Tok == token.DEFINE {
+				s.Define(ident, val)
+			} else {
+				s.Set(ident.Name, val)
+			}
 		}
 		rv := reflect.ValueOf(ranger)
 		switch rv.Type().Kind() {
 		case reflect.Array, reflect.Slice:
 			for i := 0; i < rv.Len(); i++ {
-				if len(key) > 0 {
-					s.Set(key, i)
-				}
-				if len(value) > 0 {
-					s.Set(value, rv.Index(i).Interface())
+				if err := s.checkpoint(); err != nil {
+					return nil, err
 				}
+				set(key, i)
+				set(value, rv.Index(i).Interface())
 				s.Interpret(e.Body)
 			}
 		case reflect.Map:
 			keys := rv.MapKeys()
 			for _, keyV := range keys {
-				if len(key) > 0 {
-					s.Set(key, keyV.Interface())
-				}
-				if len(value) > 0 {
-					s.Set(value, rv.MapIndex(keyV).Interface())
+				if err := s.checkpoint(); err != nil {
+					return nil, err
 				}
+				set(key, keyV.Interface())
+				set(value, rv.MapIndex(keyV).Interface())
 				s.Interpret(e.Body)
 			}
 		default:
@@ -751,20 +846,26 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		}
 		return nil, nil
 	case *ast.ValueSpec:
-		typ, err := scope.Interpret(e.Type)
-		if err != nil {
-			return nil, err
+		var zero interface{}
+		if e.Type != nil {
+			typ, err := scope.Interpret(e.Type)
+			if err != nil {
+				return nil, err
+			}
+			if err := scope.checkArray(typ.(reflect.Type)); err != nil {
+				return nil, err
+			}
+			zero = reflect.Zero(typ.(reflect.Type)).Interface()
 		}
-		zero := reflect.Zero(typ.(reflect.Type)).Interface()
 		for i, name := range e.Names {
 			if len(e.Values) > i {
 				v, err := scope.Interpret(e.Values[i])
 				if err != nil {
 					return nil, err
 				}
-				scope.Set(name.Name, v)
+				scope.Define(name, v)
 			} else {
-				scope.Set(name.Name, zero)
+				scope.Define(name, zero)
 			}
 		}
 		return nil, nil
@@ -778,6 +879,9 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		var err error
 		var last interface{}
 		for {
+			if err := s.checkpoint(); err != nil {
+				return nil, err
+			}
 			if e.Cond != nil {
 				cond, err := s.Interpret(e.Cond)
 				if err != nil {
@@ -851,6 +955,9 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		}
 
 		for {
+			if err := scope.checkpoint(); err != nil {
+				return nil, err
+			}
 			for _, cc := range clauses {
 				child := scope.NewChild()
 				child.isSelect = true
@@ -1009,8 +1116,13 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 			return nil, err
 		}
 		if cond == true {
+			currentScope.traceBranch("then")
 			return currentScope.Interpret(e.Body)
 		}
+		currentScope.traceBranch("else")
+		if e.Else == nil {
+			return nil, nil
+		}
 		return currentScope.Interpret(e.Else)
 
 	case *ast.DeferStmt:
@@ -1030,16 +1142,75 @@ func (scope *Scope) Interpret(expr ast.Node) (interface{}, error) {
 		return nil, nil
 
 	case *ast.StructType:
-		if len(e.Fields.List) > 0 {
-			return nil, errors.New("don't support non-empty structs yet")
+		return scope.structType(e)
+
+	case *ast.StarExpr:
+		X, err := scope.Interpret(e.X)
+		if err != nil {
+			return nil, err
+		}
+		if typ, isType := X.(reflect.Type); isType {
+			return reflect.PtrTo(typ), nil
 		}
-		return reflect.TypeOf(struct{}{}), nil
+		xVal := reflect.ValueOf(X)
+		if xVal.Kind() != reflect.Ptr {
+			return nil, errors.Errorf("invalid indirect of %#v", X)
+		}
+		if xVal.IsNil() {
+			return nil, errors.New("runtime error: invalid memory address or nil pointer dereference")
+		}
+		return xVal.Elem().Interface(), nil
 
 	default:
 		return nil, fmt.Errorf("unknown node %#v", e)
 	}
 }
 
+// structType builds the struct type e. Unexported fields belong to package
+// main, as if the struct was declared there.
+func (scope *Scope) structType(e *ast.StructType) (t reflect.Type, err error) {
+	var fields []reflect.StructField
+	for _, field := range e.Fields.List {
+		typ, err := scope.Interpret(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		rType, ok := typ.(reflect.Type)
+		if !ok {
+			return nil, errors.Errorf("invalid field type %#v", typ)
+		}
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			unquoted, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(unquoted)
+		}
+		names := field.Names
+		if len(names) == 0 {
+			name := rType.Name()
+			if rType.Kind() == reflect.Ptr {
+				name = rType.Elem().Name()
+			}
+			names = []*ast.Ident{{Name: name}}
+		}
+		for _, name := range names {
+			f := reflect.StructField{Name: name.Name, Type: rType, Tag: tag, Anonymous: len(field.Names) == 0}
+			if !ast.IsExported(name.Name) {
+				f.PkgPath = "main"
+			}
+			fields = append(fields, f)
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%s", r)
+		}
+	}()
+	return reflect.StructOf(fields), nil
+}
+
 func (scope *Scope) getValue(id ast.Expr) (reflect.Value, error) {
 	switch id := id.(type) {
 	case *ast.Ident:
@@ -1059,18 +1230,24 @@ func (scope *Scope) getValue(id ast.Expr) (reflect.Value, error) {
 		if err != nil {
 			return reflect.Value{}, err
 		}
+		if elem, err = derefArray(elem); err != nil {
+			return reflect.Value{}, err
+		}
 
 		switch elem.Kind() {
 		case reflect.Slice, reflect.Array:
-			indexInt, ok := index.(int)
-			if !ok {
-				return reflect.Value{}, errors.Errorf("expected index to be int, got %#v", index)
+			indexInt, err := toIndex(index)
+			if err != nil {
+				return reflect.Value{}, err
 			}
-			if indexInt >= elem.Len() {
-				return reflect.Value{}, errors.Errorf("index out of range")
+			if err := checkIndex(indexInt, elem.Len()); err != nil {
+				return reflect.Value{}, err
 			}
 			return elem.Index(indexInt), nil
 
+		case reflect.String:
+			return reflect.Value{}, errors.Errorf("cannot assign to %s (neither addressable nor a map index expression)", scope.Render(id))
+
 		case reflect.Map:
 			return elem.MapIndex(reflect.ValueOf(index)), nil
 
@@ -1083,6 +1260,12 @@ func (scope *Scope) getValue(id ast.Expr) (reflect.Value, error) {
 		if err != nil {
 			return reflect.Value{}, err
 		}
+		if elem.Type() == packageType {
+			if v, ok := elem.Interface().(Package).Var(id.Sel.Name); ok {
+				return v.Elem(), nil
+			}
+			return reflect.Value{}, errors.Errorf("cannot assign to %s", scope.Render(id))
+		}
 		return elem.FieldByName(id.Sel.Name), nil
 
 	default:
@@ -1090,12 +1273,145 @@ func (scope *Scope) getValue(id ast.Expr) (reflect.Value, error) {
 	}
 }
 
+// sliceOperand returns the value a slice expression operates on. Arrays have
+// to be addressable so the result shares their backing storage.
+func (scope *Scope) sliceOperand(x ast.Expr) (reflect.Value, error) {
+	X, err := scope.Interpret(x)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	xVal := reflect.ValueOf(X)
+	switch xVal.Kind() {
+	case reflect.String, reflect.Slice:
+		return xVal, nil
+	case reflect.Ptr:
+		return derefArray(xVal)
+	case reflect.Array:
+		addr, err := scope.getValue(x)
+		if err != nil || !addr.CanAddr() {
+			return reflect.Value{}, errors.Errorf("invalid operation: %s (slice of unaddressable value)", scope.Render(x))
+		}
+		return addr, nil
+	default:
+		return reflect.Value{}, errors.Errorf("invalid X for SliceExpr: %#v", X)
+	}
+}
+
+// derefArray follows a pointer to an array, the only pointer type Go allows
+// to be indexed or sliced directly.
+func derefArray(v reflect.Value) (reflect.Value, error) {
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Array {
+		return v, nil
+	}
+	if v.IsNil() {
+		return reflect.Value{}, errors.New("runtime error: invalid memory address or nil pointer dereference")
+	}
+	return v.Elem(), nil
+}
+
+// toIndex converts an integer of any type into an int suitable for indexing.
+func toIndex(i interface{}) (int, error) {
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(v.Uint()), nil
+	default:
+		return 0, errors.Errorf("invalid argument: index %#v must be integer", i)
+	}
+}
+
+// checkIndex returns the Go runtime error for an out of range index.
+func checkIndex(i, length int) error {
+	if i < 0 {
+		return errors.Errorf("runtime error: index out of range [%d]", i)
+	}
+	if i >= length {
+		return errors.Errorf("runtime error: index out of range [%d] with length %d", i, length)
+	}
+	return nil
+}
+
+// sliceValue computes x[low:high] or x[low:high:max] with the same bounds
+// checks and error messages as the Go runtime.
+func sliceValue(x reflect.Value, low, high, max int, slice3 bool) (interface{}, error) {
+	bound, limit := "length", x.Len()
+	if x.Kind() == reflect.Slice {
+		bound, limit = "capacity", x.Cap()
+	}
+	if slice3 {
+		switch {
+		case max < 0:
+			return nil, errors.Errorf("runtime error: slice bounds out of range [::%d]", max)
+		case max > limit:
+			return nil, errors.Errorf("runtime error: slice bounds out of range [::%d] with %s %d", max, bound, limit)
+		case high < 0:
+			return nil, errors.Errorf("runtime error: slice bounds out of range [:%d:]", high)
+		case high > max:
+			return nil, errors.Errorf("runtime error: slice bounds out of range [:%d:%d]", high, max)
+		case low < 0:
+			return nil, errors.Errorf("runtime error: slice bounds out of range [%d::]", low)
+		case low > high:
+			return nil, errors.Errorf("runtime error: slice bounds out of range [%d:%d:]", low, high)
+		}
+		return x.Slice3(low, high, max).Interface(), nil
+	}
+	switch {
+	case high < 0:
+		return nil, errors.Errorf("runtime error: slice bounds out of range [:%d]", high)
+	case high > limit:
+		return nil, errors.Errorf("runtime error: slice bounds out of range [:%d] with %s %d", high, bound, limit)
+	case low < 0:
+		return nil, errors.Errorf("runtime error: slice bounds out of range [%d:]", low)
+	case low > high:
+		return nil, errors.Errorf("runtime error: slice bounds out of range [%d:%d]", low, high)
+	}
+	return x.Slice(low, high).Interface(), nil
+}
+
+// selectMember returns the package member, method or field name of x.
+func selectMember(rVal reflect.Value, name string) (interface{}, error) {
+	if rVal.Kind() != reflect.Struct && rVal.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("%#v is not a struct and thus has no field %#v", rVal, name)
+	}
+
+	if rVal.Type() == packageType {
+		obj, isPresent := rVal.Interface().(Package).Get(name)
+		if isPresent {
+			return obj, nil
+		}
+		return nil, fmt.Errorf("unknown field %#v", name)
+	}
+
+	if method := rVal.MethodByName(name); method.IsValid() {
+		return method.Interface(), nil
+	}
+	if rVal.Kind() == reflect.Ptr {
+		rVal = rVal.Elem()
+	}
+	if field := rVal.FieldByName(name); field.IsValid() {
+		return field.Interface(), nil
+	}
+	return nil, fmt.Errorf("unknown field %#v", name)
+}
+
+// ExecuteFunc interprets funExpr and calls the resulting function with args.
 func (scope *Scope) ExecuteFunc(funExpr ast.Expr, args []interface{}) (interface{}, error) {
 	fun, err := scope.Interpret(funExpr)
 	if err != nil {
 		return nil, err
 	}
+	return scope.CallFunc(fun, args)
+}
 
+// CallFunc calls fun, which can be a Go function, an interpreted *Func or a
+// reflect.Type for conversions, with args. Multiple return values are
+// returned as a []interface{}.
+func (scope *Scope) CallFunc(fun interface{}, args []interface{}) (interface{}, error) {
+	if err := scope.checkpoint(); err != nil {
+		return nil, err
+	}
 	switch funV := fun.(type) {
 	case reflect.Type:
 		if len(args) != 1 {
@@ -1106,15 +1422,19 @@ func (scope *Scope) ExecuteFunc(funExpr ast.Expr, args []interface{}) (interface
 	case *Func:
 		// TODO enforce func return values
 		currentScope := scope.NewChild()
+		currentScope.source = funV.source
 		i := 0
 		for _, arg := range funV.Def.Type.Params.List {
 			for _, name := range arg.Names {
-				currentScope.Set(name.Name, args[i])
+				currentScope.Define(name, args[i])
 				i++
 			}
 		}
 		currentScope.isFunction = true
 		ret, err := currentScope.Interpret(funV.Def.Body)
+		if err == ErrBranchReturn {
+			ret, err = currentScope.ret, nil
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -1188,6 +1508,13 @@ func (scope *Scope) ConfigureTypes(path string, line int) error {
 	return nil
 }
 
+// isPryCall reports whether the rendered statement is the pry statement the
+// scope was captured at. It reads pry.Pry in the original files built through
+// an overlay and pry.Apply in files injected in place.
+func isPryCall(stmt string) bool {
+	return strings.HasPrefix(stmt, "pry.Pry(") || strings.HasPrefix(stmt, "pry.Apply(")
+}
+
 // walker adapts a function to satisfy the ast.Visitor interface.
 // The function return whether the walk should proceed into the node's children.
 type walker func(ast.Node) bool
@@ -1202,7 +1529,6 @@ func (w walker) Visit(node ast.Node) ast.Visitor {
 // CheckStatement checks if a statement is type safe
 func (scope *Scope) CheckStatement(node ast.Node) (errs []error) {
 	for name, file := range scope.Files {
-		name = filepath.Dir(name) + "/." + filepath.Base(name) + "pry"
 		if name == scope.path {
 			ast.Walk(walker(func(n ast.Node) bool {
 				switch s := n.(type) {
@@ -1211,7 +1537,7 @@ func (scope *Scope) CheckStatement(node ast.Node) (errs []error) {
 						pos := scope.fset.Position(stmt.Pos())
 						if pos.Line == scope.line {
 							r := scope.Render(stmt)
-							if strings.HasPrefix(r, "pry.Apply") {
+							if isPryCall(r) {
 								var iStmt []ast.Stmt
 								switch s2 := node.(type) {
 								case *ast.BlockStmt:
@@ -1258,24 +1584,39 @@ func (scope *Scope) Render(x ast.Node) string {
 	return buf.String()
 }
 
+// unusedError reports whether err complains about an unused variable or
+// import, which is expected of input at the prompt. Newer versions of go/types
+// put the name after the message.
+func unusedError(err error) bool {
+	msg := err.Error()
+	return strings.HasSuffix(msg, "not used") || strings.Contains(msg, "declared and not used: ") || strings.Contains(msg, "imported and not used")
+}
+
 // TypeCheck does type checking and returns the info object
 func (scope *Scope) TypeCheck() (*types.Info, []error) {
 	var errs []error
 	scope.config.Error = func(err error) {
-		if !strings.HasSuffix(err.Error(), "not used") {
+		if !unusedError(err) {
 			err := errors.New(strings.TrimPrefix(err.Error(), scope.path))
 			errs = append(errs, errors.Wrapf(err, "path %q", scope.path))
 		}
 	}
-	info := &types.Info{}
+	info := newTypeInfo()
 	var files []*ast.File
 	for _, f := range scope.Files {
 		files = append(files, f)
 	}
 	// these errors should be reported via the error reporter above
-	if _, err := scope.config.Check(filepath.Dir(scope.path), scope.fset, files, info); errs == nil && err != nil {
+	pkg, err := scope.config.Check(filepath.Dir(scope.path), scope.fset, files, info)
+	if _, isTypeErr := err.(types.Error); errs == nil && err != nil && !isTypeErr {
 		return nil, []error{err}
 	}
+	if len(errs) == 0 {
+		scope.Lock()
+		scope.info = info
+		scope.pkg = pkg
+		scope.Unlock()
+	}
 	return info, errs
 }
 
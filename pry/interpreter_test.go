@@ -1,9 +1,16 @@
 package pry
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"go/ast"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestEmptyString(t *testing.T) {
@@ -341,6 +348,118 @@ func TestSlice(t *testing.T) {
 	}
 }
 
+func TestSliceToEnd(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("a", []int{1, 2, 3, 4})
+
+	out, err := scope.InterpretString(`a[2:4]`)
+	if err != nil {
+		t.Error(err)
+	}
+	expected := []int{3, 4}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestSliceFull(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("a", make([]int, 4, 10))
+
+	out, err := scope.InterpretString(`a[1:3:5]`)
+	if err != nil {
+		t.Error(err)
+	}
+	s, ok := out.([]int)
+	if !ok || len(s) != 2 || cap(s) != 4 {
+		t.Errorf("Expected len 2 cap 4 got %#v.", out)
+	}
+}
+
+func TestSliceArrayPointer(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	out, err := scope.InterpretString(`
+		a := [4]int{1, 2, 3, 4}
+		b := &a
+		c := b[1:]
+		c[0] = 5
+		a[1]
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 5
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestStringIndex(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("s", "hello")
+
+	out, err := scope.InterpretString(`s[1]`)
+	if err != nil {
+		t.Error(err)
+	}
+	expected := byte('e')
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestStringSlice(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("s", "hello")
+
+	out, err := scope.InterpretString(`s[1:4]`)
+	if err != nil {
+		t.Error(err)
+	}
+	expected := "ell"
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestIndexErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expr, err string
+	}{
+		{`a[4]`, "runtime error: index out of range [4] with length 4"},
+		{`s[5]`, "runtime error: index out of range [5] with length 5"},
+		{`a[i]`, "runtime error: index out of range [-1]"},
+		{`a[:5]`, "runtime error: slice bounds out of range [:5] with capacity 4"},
+		{`s[:6]`, "runtime error: slice bounds out of range [:6] with length 5"},
+		{`a[3:2]`, "runtime error: slice bounds out of range [3:2]"},
+		{`a[1:2:5]`, "runtime error: slice bounds out of range [::5] with capacity 4"},
+		{`a[1:3:2]`, "runtime error: slice bounds out of range [:3:2]"},
+		{`s[1:2:3]`, "invalid operation: 3-index slice of string"},
+	}
+	for _, c := range cases {
+		scope := NewScope()
+		scope.Set("a", []int{1, 2, 3, 4})
+		scope.Set("s", "hello")
+		scope.Set("i", -1)
+		out, err := scope.InterpretString(c.expr)
+		if err == nil || err.Error() != c.err {
+			t.Errorf("%s: expected error %q got %v (%#v)", c.expr, c.err, err, out)
+		}
+	}
+}
+
 // Structs
 type testStruct struct {
 	A    int
@@ -439,6 +558,41 @@ func TestSelectorFunc(t *testing.T) {
 	}
 }
 
+func TestPackageVariable(t *testing.T) {
+	t.Parallel()
+
+	counter := 1
+	other := 1
+	scope := NewScope()
+	scope.Set("pkg", Package{Name: "pkg", Functions: map[string]interface{}{
+		"Counter": &counter,
+		"Pointer": &other,
+		"Type":    reflect.TypeOf(0),
+	}, Vars: map[string]bool{"Counter": true}})
+
+	out, err := scope.InterpretString(`
+		pkg.Counter += 2
+		for i := 0; i < 3; i++ {
+			pkg.Counter++
+		}
+		pkg.Counter
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 6
+	if !reflect.DeepEqual(expected, out) || counter != expected {
+		t.Errorf("Expected %#v got %#v and %#v.", expected, out, counter)
+	}
+	if out, err := scope.InterpretString(`pkg.Type`); err != nil || out != reflect.TypeOf(0) {
+		t.Errorf("Expected the type to be kept got %#v, %v.", out, err)
+	}
+	// Pointers that aren't flagged as variables are values of the package.
+	if out, err := scope.InterpretString(`pkg.Pointer`); err != nil || out != &other {
+		t.Errorf("Expected the pointer to be kept got %#v, %v.", out, err)
+	}
+}
+
 // Basic Math
 func TestBasicMath(t *testing.T) {
 	t.Parallel()
@@ -1392,6 +1546,993 @@ func TestIntMod(t *testing.T) {
 	}
 }
 
+func TestInterpretEarlyReturn(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	node, _, err := scope.ParseString(`
+		f := func(n int) int {
+			for i := 0; ; i++ {
+				if i == n {
+					return i * 2
+				}
+			}
+		}
+		f(3)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := scope.Interpret(node)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 6
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+// Static types
+
+const typedScopeSrc = `package main
+
+type fakePry struct{}
+
+func (fakePry) Pry(...interface{}) {}
+
+var pry fakePry
+
+type testCounter struct {
+	N int
+}
+
+func (c *testCounter) Inc() { c.N++ }
+
+func main() {
+	c := testCounter{}
+	_ = c
+	pry.Pry()
+}
+`
+
+// testTypedScope returns a scope type checked against typedScopeSrc, paused at
+// the pry.Pry call.
+func testTypedScope(t *testing.T) *Scope {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir(wd, "go-pry-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(typedScopeSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scope := NewScope()
+	if err := scope.ConfigureTypes(filepath.Join(dir, "main.go"), 18); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return scope
+}
+
+func TestTypedUntypedConstant(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	out, err := scope.InterpretString(`
+		var f float64 = 1
+		f / 2
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 0.5
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestTypedMixedConstant(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	out, err := scope.InterpretString(`
+		x := uint8(3)
+		x + 250
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := uint8(253)
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestTypedInterfaceVariable(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	out, err := scope.InterpretString(`
+		var v interface{}
+		v = 1
+		v = "a"
+		v
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := "a"
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+type testCounter struct {
+	N int
+}
+
+func (c *testCounter) Inc() { c.N++ }
+
+func TestTypedPointerMethod(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Set("c", testCounter{})
+	out, err := scope.InterpretString(`
+		c.Inc()
+		c.N
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 1
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+// Compilation
+
+func TestCompiledLoop(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("n", 10)
+	node, _, err := scope.ParseString(`
+		sum := 0
+		for i := 0; i < n; i++ {
+			if i%2 == 0 {
+				continue
+			}
+			sum += i
+		}
+		sum
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := scope.Compile(node)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	out, err := prog.Run()
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 25
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+	if sum, _ := scope.Get("sum"); !reflect.DeepEqual(expected, sum) {
+		t.Errorf("Expected sum to be exported as %#v got %#v.", expected, sum)
+	}
+	if _, exists := scope.Get("i"); exists {
+		t.Errorf("Expected loop variable to stay local")
+	}
+}
+
+func TestCompiledPointer(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	out, err := scope.InterpretString(`
+		a := 1
+		b := &a
+		a = 5
+		*b
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 5
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestCompileUnsupported(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	node, _, err := scope.ParseString(`
+		i := 0
+		switch {
+		default:
+			i = 1
+		}
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scope.Compile(node); err != ErrNotCompilable {
+		t.Errorf("Expected ErrNotCompilable got %v", err)
+	}
+	out, err := scope.Interpret(node)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 1
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+const benchmarkLoop = `
+	sum := 0
+	for i := 0; i < 1000; i++ {
+		sum += i * 2
+	}
+	sum
+`
+
+func BenchmarkTreeWalkLoop(b *testing.B) {
+	scope := NewScope()
+	node, _, err := scope.ParseString(benchmarkLoop)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := scope.Interpret(node); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledLoop(b *testing.B) {
+	scope := NewScope()
+	node, _, err := scope.ParseString(benchmarkLoop)
+	if err != nil {
+		b.Fatal(err)
+	}
+	prog, err := scope.Compile(node)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := prog.Run(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Cancellation
+
+func TestInterruptLoop(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := scope.InterpretStringContext(ctx, `
+		a := 0
+		for {
+			a++
+		}
+	`)
+	if err != ErrInterrupted {
+		t.Errorf("Expected %v got %v.", ErrInterrupted, err)
+	}
+	if a, _ := scope.Get("a"); a.(int) <= 0 {
+		t.Errorf("Expected the loop's progress to be kept, got %#v.", a)
+	}
+}
+
+func TestInterruptChannel(t *testing.T) {
+	t.Parallel()
+
+	for _, src := range []string{
+		`ch := make(chan int); <-ch`,
+		`select {}`,
+	} {
+		scope := NewScope()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_, err := scope.InterpretStringContext(ctx, src)
+		cancel()
+		if err != ErrInterrupted {
+			t.Errorf("%s: Expected %v got %v.", src, ErrInterrupted, err)
+		}
+	}
+}
+
+// Limits
+
+// expectLimit fails the test unless err is a LimitError of kind.
+func expectLimit(t *testing.T, err error, kind LimitKind) {
+	t.Helper()
+	if lErr, ok := err.(*LimitError); !ok || lErr.Kind != kind {
+		t.Errorf("Expected a %s limit error got %#v.", kind, err)
+	}
+}
+
+func TestLimitSteps(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.SetLimits(Limits{MaxSteps: 100})
+	_, err := scope.InterpretString(`
+		a := 0
+		for i := 0; i < 1000; i++ {
+			a++
+		}
+	`)
+	expectLimit(t, err, StepLimit)
+
+	// The budget is per evaluation.
+	out, err := scope.InterpretString(`
+		b := 0
+		for i := 0; i < 10; i++ {
+			b++
+		}
+		b
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 10
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestLimitTimeout(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.SetLimits(Limits{Timeout: 20 * time.Millisecond})
+	_, err := scope.InterpretString(`for {}`)
+	expectLimit(t, err, DeadlineLimit)
+}
+
+func TestLimitGoroutines(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.SetLimits(Limits{MaxGoroutines: 1})
+	_, err := scope.InterpretString(`
+		ch := make(chan int)
+		go func() { <-ch }()
+		go func() { <-ch }()
+	`)
+	expectLimit(t, err, GoroutineLimit)
+	if _, err := scope.InterpretString(`close(ch)`); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestLimitCollectionSize(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.SetLimits(Limits{MaxCollectionSize: 3})
+	for _, src := range []string{
+		`make([]int, 4)`,
+		`make([]int, 0, 10)`,
+		`append([]int{1, 2, 3}, 4)`,
+		`a := []int{1, 2}; for i := 0; i < 10; i++ { a = append(a, i) }`,
+		`[4]int{}`,
+		`var a [2][2]int`,
+		`make([]int, int64(4))`,
+		`make([]int, 0, uint8(4))`,
+	} {
+		_, err := scope.InterpretString(src)
+		expectLimit(t, err, CollectionSizeLimit)
+	}
+}
+
+func TestLimitCollectionSizeEngines(t *testing.T) {
+	t.Parallel()
+
+	for _, engine := range []Engine{ReflectEngine{}, SSAEngine{}} {
+		scope := testTypedScope(t)
+		scope.Engine = engine
+		scope.SetLimits(Limits{MaxCollectionSize: 3})
+		for _, src := range []string{
+			`make([]int, 4)`,
+			`make([]int, int64(4))`,
+			`make([]int, 0, uint(4))`,
+			`var arr [4]int; arr`,
+			`[2][2]int{}`,
+			`s3 := []int{1, 2, 3}; append(s3, 4)`,
+		} {
+			_, err := scope.Eval(src)
+			if err == nil {
+				t.Errorf("%T: expected %q to exceed the limit", engine, src)
+				continue
+			}
+			expectLimit(t, err, CollectionSizeLimit)
+		}
+		if _, err := scope.Eval(`make([]int, int64(3))`); err != nil {
+			t.Errorf("%T: %+v", engine, err)
+		}
+	}
+}
+
+// Goroutines
+
+// waitGoroutine waits for the goroutine with the given ID to exit.
+func waitGoroutine(t *testing.T, scope *Scope, id int) *Goroutine {
+	t.Helper()
+	g, ok := scope.Goroutine(id)
+	if !ok {
+		t.Fatalf("goroutine %d not found", id)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := g.Wait(ctx); err == ErrInterrupted {
+		t.Fatalf("goroutine %d didn't exit", id)
+	}
+	return g
+}
+
+func TestGoroutineRegistry(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	out, err := scope.InterpretString(`
+		ch := make(chan int)
+		go func() { ch <- 1 }()
+		<-ch
+	`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if out != 1 {
+		t.Errorf("Expected 1 got %#v.", out)
+	}
+	g := waitGoroutine(t, scope, 1)
+	if g.State() != GoroutineFinished || g.Err() != nil {
+		t.Errorf("Expected finished got %s: %v.", g.State(), g.Err())
+	}
+	expected := "go func() { ch <- 1 }()"
+	if g.Source != expected {
+		t.Errorf("Expected %#v got %#v.", expected, g.Source)
+	}
+}
+
+func TestGoroutineFailed(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	if _, err := scope.InterpretString(`go func() { a := []int{}; a[1] = 2 }()`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	g := waitGoroutine(t, scope, 1)
+	if g.State() != GoroutineFailed || g.Err() == nil {
+		t.Errorf("Expected failed got %s: %v.", g.State(), g.Err())
+	}
+	if failed := scope.failedGoroutines(); len(failed) != 1 || failed[0] != g {
+		t.Errorf("Expected the failure to be reported got %#v.", failed)
+	}
+	if failed := scope.failedGoroutines(); len(failed) != 0 {
+		t.Errorf("Expected the failure to be reported once got %#v.", failed)
+	}
+}
+
+func TestGoroutinePrune(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	total := maxExitedGoroutines + 10
+	for i := 0; i < total; i++ {
+		if err := scope.spawn("go f()", func(ctx context.Context) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+		all := scope.Goroutines()
+		<-all[len(all)-1].Done()
+	}
+	all := scope.Goroutines()
+	if len(all) != maxExitedGoroutines+1 {
+		t.Fatalf("Expected %d goroutines got %d.", maxExitedGoroutines+1, len(all))
+	}
+	if all[0].ID != total-maxExitedGoroutines || all[len(all)-1].ID != total {
+		t.Errorf("Expected the newest goroutines to be kept got %d to %d.", all[0].ID, all[len(all)-1].ID)
+	}
+}
+
+func TestGoroutineCancel(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	if _, err := scope.InterpretString(`go func() { for {} }()`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	g, _ := scope.Goroutine(1)
+	if g.State() != GoroutineRunning {
+		t.Errorf("Expected running got %s.", g.State())
+	}
+	g.Cancel()
+	g = waitGoroutine(t, scope, 1)
+	if g.State() != GoroutineCancelled {
+		t.Errorf("Expected cancelled got %s: %v.", g.State(), g.Err())
+	}
+}
+
+// Explain
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("a", []int{1, 2, 3})
+	scope.Set("b", 3)
+	ex, err := scope.Explain(`a[1] == b`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := &Explanation{
+		Source: "a[1] == b",
+		Result: false,
+		Values: []ExplainedValue{
+			{Expr: "a", Offset: 0, Value: []int{1, 2, 3}},
+			{Expr: "a[1]", Offset: 1, Value: 2},
+			{Expr: "a[1] == b", Offset: 5, Value: false},
+			{Expr: "b", Offset: 8, Value: 3},
+		},
+	}
+	if !reflect.DeepEqual(expected, ex) {
+		t.Errorf("Expected %#v got %#v.", expected, ex)
+	}
+	diagram := `a[1] == b
+||   |  |
+||   |  3
+||   false
+|2
+[]int{1, 2, 3}
+`
+	if ex.String() != diagram {
+		t.Errorf("Expected\n%s\ngot\n%s", diagram, ex)
+	}
+}
+
+func TestExplainCall(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Set("s", "abc")
+	ex, err := scope.Explain(`!(len(s)+1 < 2)`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := []ExplainedValue{
+		{Expr: "!(len(s)+1 < 2)", Offset: 0, Value: true},
+		{Expr: "len(s)", Offset: 2, Value: 3},
+		{Expr: "s", Offset: 6, Value: "abc"},
+		{Expr: "len(s)+1", Offset: 8, Value: 4},
+		{Expr: "len(s)+1 < 2", Offset: 11, Value: false},
+	}
+	if !reflect.DeepEqual(expected, ex.Values) {
+		t.Errorf("Expected %#v got %#v.", expected, ex.Values)
+	}
+}
+
+// Bindings
+
+func TestKeysParents(t *testing.T) {
+	t.Parallel()
+
+	root := NewScope()
+	root.Set("a", 1)
+	child := root.NewChild().NewChild()
+	child.Set("b", 2)
+	keys := map[string]bool{}
+	for _, k := range child.Keys() {
+		keys[k] = true
+	}
+	if !keys["a"] || !keys["b"] {
+		t.Errorf("Expected a and b got %#v.", child.Keys())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	root := NewScope()
+	root.Set("a", 1)
+	child := root.NewChild()
+	child.Define(&ast.Ident{Name: "a"}, 2)
+	if !child.Delete("a") {
+		t.Errorf("Expected a to be deleted")
+	}
+	if v, _ := child.Get("a"); v != 1 {
+		t.Errorf("Expected the parent binding 1 got %#v.", v)
+	}
+	if !child.Delete("a") || child.Delete("a") {
+		t.Errorf("Expected a to be deleted once more")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	root := &Scope{Vals: map[string]interface{}{
+		"a":       1,
+		"strings": Package{Name: "strings"},
+	}}
+	root.markCaptured()
+	child := root.NewChild()
+	child.Define(&ast.Ident{Name: "a"}, "shadow")
+	child.Delete("_pryScope")
+
+	var bindings []Binding
+	child.Walk(func(b Binding) bool {
+		bindings = append(bindings, b)
+		return true
+	})
+	expected := []Binding{
+		{Name: "a", Kind: DefinedBinding, Value: "shadow", Type: reflect.TypeOf(""), Depth: 0},
+		{Name: "a", Kind: CapturedBinding, Value: 1, Type: reflect.TypeOf(1), Depth: 1},
+		{Name: "strings", Kind: PackageBinding, Value: Package{Name: "strings"}, Type: packageType, Depth: 1},
+	}
+	if !reflect.DeepEqual(expected, bindings) {
+		t.Errorf("Expected %#v got %#v.", expected, bindings)
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	if _, err := scope.InterpretString(`var e error; n := 1`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for name, expected := range map[string]reflect.Type{
+		"e": reflect.TypeOf((*error)(nil)).Elem(),
+		"n": reflect.TypeOf(1),
+	} {
+		typ, ok := scope.TypeOf(name)
+		if !ok || typ != expected {
+			t.Errorf("%s: Expected %s got %s.", name, expected, typ)
+		}
+	}
+	if _, ok := scope.TypeOf("missing"); ok {
+		t.Errorf("Expected missing to be unbound")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	if _, err := scope.InterpretString(`a := 1`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	snap := scope.Snapshot()
+	if _, err := snap.InterpretString(`a = 2; b := "snap"`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if v, _ := scope.Get("a"); v != 1 {
+		t.Errorf("Expected the original a to be 1 got %#v.", v)
+	}
+	if v, _ := snap.Get("a"); v != 2 {
+		t.Errorf("Expected the snapshot a to be 2 got %#v.", v)
+	}
+	// b was only declared in the snapshot.
+	out, err := scope.InterpretString(`b := 3; b`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if out != 3 {
+		t.Errorf("Expected 3 got %#v.", out)
+	}
+	if v, _ := snap.Get("_pryScope"); v != snap {
+		t.Errorf("Expected _pryScope to be the snapshot")
+	}
+}
+
+func TestCapturedByReference(t *testing.T) {
+	t.Parallel()
+
+	a := 1
+	p := &a
+	s := struct{ N int }{}
+	scope := NewScope()
+	scope.Vals["a"] = &a
+	scope.Vals["p"] = &p
+	scope.Vals["s"] = &s
+	scope.markCaptured()
+	if _, err := scope.InterpretString(`
+		a = 5
+		a++
+		*p *= 2
+		s.N = a
+		func() { a++ }()
+	`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if a != 13 || s.N != 12 {
+		t.Errorf("Expected the program to observe the changes, got a = %d, s.N = %d.", a, s.N)
+	}
+	if v, _ := scope.Get("p"); v != p {
+		t.Errorf("Expected %#v got %#v.", p, v)
+	}
+}
+
+type capturedType struct {
+	A int
+}
+
+// Types are captured as their reflect.Type, like go-pry generates them.
+func TestCapturedType(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Vals["T"] = Type((*capturedType)(nil)).Elem()
+	scope.markCaptured()
+	out, err := scope.InterpretString(`
+		var z T
+		z.A = T{A: 2}.A + 1
+		z
+	`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if expected := (capturedType{A: 3}); out != expected {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+	if v, _ := scope.Get("T"); v != reflect.TypeOf(capturedType{}) {
+		t.Errorf("Expected the type, got %#v.", v)
+	}
+	snapshot := scope.Snapshot()
+	if v, _ := snapshot.Get("T"); v != reflect.TypeOf(capturedType{}) {
+		t.Errorf("Expected the snapshot to keep the type, got %#v.", v)
+	}
+	if typ, _ := scope.TypeOf("T"); typ != reflect.TypeOf(reflect.TypeOf(0)) {
+		t.Errorf("Expected the binding of a reflect.Type, got %v.", typ)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	t.Parallel()
+
+	global, local := 1, 2
+	RegisterPackage("example.com/capture", "capture", map[string]interface{}{
+		"global": &global,
+		"shadow": &global,
+		"T":      Type((*capturedType)(nil)).Elem(),
+	})
+	scope := Capture("example.com/capture", map[string]string{"et": "github.com/d4l3k/go-pry/pry/embedtest"}, map[string]interface{}{
+		"shadow": &local,
+	})
+	scope.markCaptured()
+	out, err := scope.InterpretString(`global = et.Double(global); shadow + global`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if out != 4 || global != 2 {
+		t.Errorf("Expected 4 and global = 2, got %#v and %d.", out, global)
+	}
+	out, err = scope.InterpretString(`T{A: global}`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if expected := (capturedType{A: 2}); out != expected {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+// Tracing
+
+func TestTrace(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	scope := NewScope()
+	scope.SetTrace(&buf)
+	_, err := scope.InterpretString(`
+		double := func(x int) int { return x * 2 }
+		a := double(2)
+		if a > 3 {
+			a--
+		} else {
+			a++
+		}
+		switch a {
+		case 1, 2:
+			a = 0
+		default:
+			a = 10
+		}
+	`)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := `1:1    double := func(x int) int { return x * 2 }
+         double = func(x int) int {...}
+2:3    a := double(2)
+1:29   return x * 2
+         a = 4
+3:3    if a > 3 {
+         -> then
+4:4    a--
+         a = 3
+8:3    switch a {
+11:3     -> default:
+12:4   a = 10
+         a = 10
+`
+	if buf.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	scope.SetTrace(nil)
+	if _, err := scope.InterpretString(`a = 1`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no trace got %q.", buf.String())
+	}
+}
+
+// SSA engine
+
+func TestSSAEngineLoop(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	if _, err := scope.Eval(`
+		a := 0
+		for i := 0; i < 10; i++ {
+			a += i
+		}
+	`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	out, err := scope.Eval(`a * 2`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 90
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestSSAEngineCapturedVariable(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	scope.Set("testCounter", reflect.TypeOf(testCounter{}))
+	scope.Set("c", testCounter{})
+	out, err := scope.Eval(`
+		c.Inc()
+		c.N += 10
+		c.N
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := 11
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+	if c, _ := scope.Get("c"); c.(testCounter).N != 11 {
+		t.Errorf("Expected the scope to observe the change, got %#v.", c)
+	}
+}
+
+func TestSSAEngineRecover(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	out, err := scope.Eval(`
+		div := func(a, b int) (r int) {
+			defer func() {
+				if recover() != nil {
+					r = -1
+				}
+			}()
+			return a / b
+		}
+		[]int{div(6, 3), div(1, 0)}
+	`)
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	expected := []int{2, -1}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestSSAEngineError(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	_, err := scope.Eval(`
+		m := map[string][]int{}
+		m["a"][1]
+	`)
+	expected := "runtime error: index out of range [1] with length 0"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %q got %v.", expected, err)
+	}
+}
+
+func TestSSAEngineInterrupt(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := scope.EvalContext(ctx, `for {}`); err != ErrInterrupted {
+		t.Errorf("Expected %v got %v.", ErrInterrupted, err)
+	}
+}
+
+func TestSSAEngineLimits(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	scope.SetLimits(Limits{MaxSteps: 100, MaxCollectionSize: 10})
+	_, err := scope.Eval(`for {}`)
+	expectLimit(t, err, StepLimit)
+	_, err = scope.Eval(`n := 11; make([]int, n)`)
+	expectLimit(t, err, CollectionSizeLimit)
+}
+
+func TestSSAEngineGoroutines(t *testing.T) {
+	t.Parallel()
+
+	scope := testTypedScope(t)
+	scope.Engine = SSAEngine{}
+	if _, err := scope.Eval(`go func() { for {} }()`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	g, _ := scope.Goroutine(1)
+	expected := "go func() { for { } }()"
+	if g.Source != expected {
+		t.Errorf("Expected %#v got %#v.", expected, g.Source)
+	}
+	g.Cancel()
+	if g = waitGoroutine(t, scope, 1); g.State() != GoroutineCancelled {
+		t.Errorf("Expected cancelled got %s: %v.", g.State(), g.Err())
+	}
+}
+
+func TestSSAEngineRequiresTypes(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	scope.Engine = SSAEngine{}
+	if _, err := scope.Eval(`1 + 1`); err == nil {
+		t.Error("Expected an error evaluating without type information.")
+	}
+}
+
 // TODO Packages
 
 // TODO References
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"log"
+	"os"
+	"os/signal"
 	"path"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -72,3 +74,11 @@ func (h ioHistory) Len() int { return len(h.Records) }
 func (h *ioHistory) Add(record string) {
 	h.Records = append(h.Records, record)
 }
+
+// notifyInterrupt relays interrupt signals to the returned channel until stop
+// is called.
+func notifyInterrupt() (<-chan os.Signal, func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	return c, func() { signal.Stop(c) }
+}
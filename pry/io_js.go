@@ -6,12 +6,15 @@ import (
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"syscall/js"
 )
 
+// readFile fetches the original of the file generated into the bundles with
+// go-pry -generate, which is kept next to it as .file.gopry.
 func readFile(path string) ([]byte, error) {
-	path = filepath.Join("bundles", filepath.Base(path))
+	path = filepath.Join("bundles", "."+filepath.Base(path)+"pry")
 
 	r, w := io.Pipe()
 	var respCB js.Func
@@ -81,3 +84,9 @@ func (bh browserHistory) Len() int { return len(bh.Records) }
 func (bh *browserHistory) Add(record string) {
 	bh.Records = append(bh.Records, record)
 }
+
+// notifyInterrupt returns a nil channel since there are no signals in the
+// browser. Ctrl-C is delivered through the terminal instead.
+func notifyInterrupt() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
@@ -0,0 +1,228 @@
+package pry
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Limits bounds the resources evaluations in a scope may use, so input can be
+// evaluated against a live program without taking it down. Zero values mean
+// no limit.
+type Limits struct {
+	// MaxSteps bounds the loop iterations and calls of each evaluation.
+	MaxSteps int64
+	// Timeout bounds the wall-clock time of each evaluation.
+	Timeout time.Duration
+	// MaxGoroutines bounds the number of goroutines started by evaluations
+	// that may run at the same time.
+	MaxGoroutines int64
+	// MaxCollectionSize bounds the length and capacity of the slices, maps
+	// and channel buffers created by make and append.
+	MaxCollectionSize int
+}
+
+// LimitKind identifies one of the Limits.
+type LimitKind int
+
+// The kinds of limits.
+const (
+	StepLimit LimitKind = iota
+	DeadlineLimit
+	GoroutineLimit
+	CollectionSizeLimit
+)
+
+func (k LimitKind) String() string {
+	switch k {
+	case StepLimit:
+		return "step"
+	case DeadlineLimit:
+		return "deadline"
+	case GoroutineLimit:
+		return "goroutine"
+	case CollectionSizeLimit:
+		return "collection size"
+	}
+	return fmt.Sprintf("LimitKind(%d)", int(k))
+}
+
+// LimitError is returned when an evaluation exceeds one of the Limits of its
+// scope.
+type LimitError struct {
+	Kind LimitKind
+	// Max is the configured limit. For DeadlineLimit it's the Timeout.
+	Max int64
+	// Size is the requested size for CollectionSizeLimit.
+	Size int64
+}
+
+func (e *LimitError) Error() string {
+	switch e.Kind {
+	case DeadlineLimit:
+		return fmt.Sprintf("deadline of %s exceeded", time.Duration(e.Max))
+	case CollectionSizeLimit:
+		return fmt.Sprintf("collection size %d exceeds limit of %d", e.Size, e.Max)
+	}
+	return fmt.Sprintf("%s limit of %d exceeded", e.Kind, e.Max)
+}
+
+// budget tracks the resources used against the limits of a scope.
+type budget struct {
+	Limits
+	steps      int64
+	goroutines int64
+}
+
+// SetLimits applies limits to evaluations in scope and its children.
+func (scope *Scope) SetLimits(limits Limits) {
+	scope.Lock()
+	defer scope.Unlock()
+	scope.budget = &budget{Limits: limits}
+}
+
+// limits returns the budget of the scope, or nil if it's unlimited.
+func (scope *Scope) limits() *budget {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		b := s.budget
+		s.Unlock()
+		if b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// beginEval prepares scope for a top level evaluation under ctx. It applies
+// the timeout and resets the step count. The returned context is the one the
+// evaluation runs under, and end must be called once it's done.
+func (scope *Scope) beginEval(ctx context.Context) (_ context.Context, end func()) {
+	cancel := func() {}
+	if b := scope.limits(); b != nil {
+		atomic.StoreInt64(&b.steps, 0)
+		if b.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, b.Timeout)
+		}
+	}
+	restore := scope.withContext(ctx)
+	return ctx, func() {
+		restore()
+		cancel()
+	}
+}
+
+// contextError converts the error of a done context into the error the
+// evaluation fails with. Deadlines set by callers rather than the Timeout of
+// the budget count as interruptions.
+func (b *budget) contextError(ctx context.Context) error {
+	if b == nil || b.Timeout <= 0 || ctx.Err() != context.DeadlineExceeded {
+		return ErrInterrupted
+	}
+	return &LimitError{Kind: DeadlineLimit, Max: int64(b.Timeout)}
+}
+
+// step takes a step from the budget.
+func (b *budget) step() error {
+	if b == nil || b.MaxSteps <= 0 {
+		return nil
+	}
+	if atomic.AddInt64(&b.steps, 1) > b.MaxSteps {
+		return &LimitError{Kind: StepLimit, Max: b.MaxSteps}
+	}
+	return nil
+}
+
+// startGoroutine reserves a goroutine from the budget. The returned function
+// releases it once the goroutine exits.
+func (b *budget) startGoroutine() (func(), error) {
+	if b == nil || b.MaxGoroutines <= 0 {
+		return func() {}, nil
+	}
+	if atomic.AddInt64(&b.goroutines, 1) > b.MaxGoroutines {
+		atomic.AddInt64(&b.goroutines, -1)
+		return nil, &LimitError{Kind: GoroutineLimit, Max: b.MaxGoroutines}
+	}
+	return func() { atomic.AddInt64(&b.goroutines, -1) }, nil
+}
+
+// checkSize returns an error if a collection of size n would exceed the
+// budget.
+func (b *budget) checkSize(n int) error {
+	if b == nil || b.MaxCollectionSize <= 0 || n <= b.MaxCollectionSize {
+		return nil
+	}
+	return &LimitError{Kind: CollectionSizeLimit, Max: int64(b.MaxCollectionSize), Size: int64(n)}
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// sizeOf returns the size given by an integer of any kind, clamped to the
+// range of an int.
+func sizeOf(arg interface{}) (int, bool) {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := v.Int(); n < int64(maxInt) {
+			return int(n), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := v.Uint(); n < uint64(maxInt) {
+			return int(n), true
+		}
+	default:
+		return 0, false
+	}
+	return maxInt, true
+}
+
+// arraySize returns the number of elements of an array of n elements of type
+// elem, counting the elements of nested arrays.
+func arraySize(n int, elem reflect.Type) int {
+	for ; elem.Kind() == reflect.Array; elem = elem.Elem() {
+		if elem.Len() != 0 && n > maxInt/elem.Len() {
+			return maxInt
+		}
+		n *= elem.Len()
+	}
+	return n
+}
+
+// checkArray returns an error if allocating a value of type t would exceed the
+// collection size limit of scope because it's a too large array.
+func (scope *Scope) checkArray(t reflect.Type) error {
+	if t.Kind() != reflect.Array {
+		return nil
+	}
+	return scope.limits().checkSize(arraySize(t.Len(), t.Elem()))
+}
+
+// limitedMake is Make with the collection size limit of scope applied.
+func (scope *Scope) limitedMake(t interface{}, args ...interface{}) (interface{}, *InterpretError) {
+	b := scope.limits()
+	sizes := make([]interface{}, len(args))
+	for i, arg := range args {
+		sizes[i] = arg
+		if n, ok := sizeOf(arg); ok {
+			if err := b.checkSize(n); err != nil {
+				return nil, &InterpretError{err}
+			}
+			sizes[i] = n
+		}
+	}
+	return Make(t, sizes...)
+}
+
+// limitedAppend is Append with the collection size limit of scope applied.
+func (scope *Scope) limitedAppend(arr interface{}, elems ...interface{}) (interface{}, *InterpretError) {
+	n := len(elems)
+	if v := reflect.ValueOf(arr); v.Kind() == reflect.Slice {
+		n += v.Len()
+	}
+	if err := scope.limits().checkSize(n); err != nil {
+		return nil, &InterpretError{err}
+	}
+	return Append(arr, elems...)
+}
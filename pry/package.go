@@ -1,9 +1,18 @@
 package pry
 
-// Package represents a Go package for use with pry
+import "reflect"
+
+var packageType = reflect.TypeOf(Package{})
+
+// Package represents a Go package for use with pry. Variables are stored in
+// Functions as pointers to them so the package and the input share them, and
+// flagged in Vars. Other pointers are plain values. Path is the import path of
+// registered packages.
 type Package struct {
 	Name      string
+	Path      string
 	Functions map[string]interface{}
+	Vars      map[string]bool
 }
 
 func (p Package) Keys() []string {
@@ -14,7 +23,36 @@ func (p Package) Keys() []string {
 	return keys
 }
 
+// Get returns the member key of the package. It returns the current value of
+// variables.
 func (p Package) Get(key string) (interface{}, bool) {
+	if v, ok := p.Var(key); ok {
+		return v.Elem().Interface(), true
+	}
 	v, ok := p.Functions[key]
 	return v, ok
 }
+
+// Var returns the pointer to the package variable key.
+func (p Package) Var(key string) (reflect.Value, bool) {
+	if !p.Vars[key] {
+		return reflect.Value{}, false
+	}
+	rv, ok := varPointer(p.Functions[key])
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+// varPointer returns v if it's a pointer to a variable.
+func varPointer(v interface{}) (reflect.Value, bool) {
+	if _, isType := v.(reflect.Type); isType {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
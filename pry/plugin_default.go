@@ -0,0 +1,146 @@
+// +build !js
+
+package pry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"plugin"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// loadPlugin builds a plugin registering the symbol table of the package with
+// the import path in the module of dir and loads it.
+func loadPlugin(ctx context.Context, dir, path string) error {
+	version, err := moduleVersion(ctx, dir, path)
+	if err != nil {
+		return err
+	}
+	so := ""
+	if version != "" {
+		so, err = pluginCachePath(path, version)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(so); err == nil {
+			_, err := plugin.Open(so)
+			return err
+		}
+	}
+
+	tmp, err := ioutil.TempDir("", "go-pry-plugin")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if so == "" {
+		so = filepath.Join(tmp, "plugin.so")
+	}
+
+	pkg, err := getImporter().ImportFrom(path, dir, 0)
+	if err != nil {
+		return err
+	}
+	src, err := SymbolTable("main", []*types.Package{pkg})
+	if err != nil {
+		return err
+	}
+	main := filepath.Join(tmp, "main.go")
+	if err := ioutil.WriteFile(main, src, 0644); err != nil {
+		return err
+	}
+	// Build next to the cached plugin and move it in place, so concurrent
+	// imports never load a partial file.
+	out := filepath.Join(filepath.Dir(so), "."+filepath.Base(so)+".tmp")
+	cmd := exec.CommandContext(ctx, "go", "build", "-buildmode=plugin", "-o", out, main)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Errorf("building plugin: %s\n%s", err, output)
+	}
+	if err := os.Rename(out, so); err != nil {
+		return err
+	}
+	_, err = plugin.Open(so)
+	return err
+}
+
+// moduleVersion returns the version of the module providing the package with
+// the import path, as seen from dir. Standard library packages have the
+// version of Go. It's empty for packages of the main module and replaced
+// modules, which can change at any time.
+func moduleVersion(ctx context.Context, dir, path string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-f", "{{if .Standard}}std{{else}}{{with .Module}}{{if not .Replace}}{{.Path}}@{{.Version}}{{end}}{{end}}{{end}}", path)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Errorf("go list: %s\n%s", err, stderr.String())
+	}
+	version := strings.TrimSpace(string(out))
+	if version == "std" {
+		return runtime.Version(), nil
+	}
+	if strings.HasSuffix(version, "@") {
+		return "", nil
+	}
+	return version, nil
+}
+
+// pluginCachePath returns the file the plugin of the package with the import
+// path and module version is cached in. Plugins only load into programs built
+// with the same Go version and the same version of this package, so those are
+// part of the key.
+func pluginCachePath(path, version string) (string, error) {
+	dir := PluginCacheDir()
+	if dir == "" {
+		return "", errors.New("no user cache directory")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	key := strings.Join([]string{path, version, runtime.Version(), runtime.GOOS, runtime.GOARCH, pryVersion()}, "\n")
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".so"), nil
+}
+
+// PluginCacheDir returns the directory the plugins of imported packages are
+// cached in, or "" if the user has no cache directory.
+func PluginCacheDir() string {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cache, "go-pry", "plugins")
+}
+
+// pryVersion returns the module version of go-pry the program was built with.
+func pryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == "github.com/d4l3k/go-pry" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/d4l3k/go-pry" {
+			if dep.Replace != nil {
+				return dep.Replace.Path + "@" + dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
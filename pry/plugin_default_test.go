@@ -0,0 +1,24 @@
+// +build !js
+
+package pry
+
+import (
+	"context"
+	"runtime"
+	"testing"
+)
+
+func TestModuleVersion(t *testing.T) {
+	t.Parallel()
+
+	version, err := moduleVersion(context.Background(), ".", "strings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != runtime.Version() {
+		t.Errorf("Expected %q got %q.", runtime.Version(), version)
+	}
+	if version, err := moduleVersion(context.Background(), ".", "github.com/d4l3k/go-pry/pry"); err != nil || version != "" {
+		t.Errorf("Expected the main module to have no version got %q, %v.", version, err)
+	}
+}
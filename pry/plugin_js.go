@@ -0,0 +1,19 @@
+// +build js
+
+package pry
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// loadPlugin fails since plugins can't be built or loaded in the browser.
+func loadPlugin(ctx context.Context, dir, path string) error {
+	return errors.New("plugins are not supported in the browser")
+}
+
+// PluginCacheDir returns "" since there are no plugins in the browser.
+func PluginCacheDir() string {
+	return ""
+}
@@ -0,0 +1,88 @@
+//go:build cgo && (linux || darwin || freebsd)
+// +build cgo
+// +build linux darwin freebsd
+
+package pry
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const pluginLibSrc = `package lib
+
+type Pair struct{ A, B int }
+
+func Sum(p Pair) int { return p.A + p.B }
+`
+
+const pluginMainSrc = `package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func main() {
+	scope := pry.NewScope()
+	if _, err := scope.Import(context.Background(), os.Args[1], ""); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	out, err := scope.InterpretString("lib.Sum(lib.Pair{A: 1, B: 2})")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println(out)
+}
+`
+
+// TestLoadPlugin imports a package of the main module into a program, which
+// builds the generated symbol table of the package into a plugin and loads it.
+// The test binary can't load the plugin itself, since it's built with a
+// different version of this package.
+func TestLoadPlugin(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir(wd, "go-pry-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := os.Mkdir(filepath.Join(dir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "lib", "lib.go"), []byte(pluginLibSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(pluginMainSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bin := filepath.Join(dir, "main")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = dir
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	run := exec.Command(bin, "github.com/d4l3k/go-pry/pry/"+filepath.Base(dir)+"/lib")
+	run.Dir = dir
+	out, err := run.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	if string(out) != "3\n" {
+		t.Errorf("Expected %q got %q.", "3\n", out)
+	}
+}
@@ -1,14 +1,20 @@
 package pry
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
-	"path/filepath"
+	"os"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
 
 	"github.com/mgutz/ansi"
 )
@@ -22,10 +28,23 @@ func Apply(scope *Scope) {
 	out, tty := openTTY()
 	defer tty.Close()
 
-	_, filePathRaw, lineNum, _ := runtime.Caller(1)
-	filePath := filepath.Dir(filePathRaw) + "/." + filepath.Base(filePathRaw) + "pry"
+	// go-pry builds the injected files through an overlay, so the file
+	// reported for the caller is the original one, with pry.Pry on the line.
+	_, filePath, lineNum, _ := runtime.Caller(1)
 
-	if err := apply(scope, out, tty, filePath, filePathRaw, lineNum); err != nil {
+	if err := apply(scope, out, tty, filePath, lineNum); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+// REPL drops into a pry shell with scope outside of a program, such as the one
+// go-pry starts when all the imports have symbol tables. Input isn't type
+// checked since there's no source to check it against.
+func REPL(scope *Scope) {
+	out, tty := openTTY()
+	defer tty.Close()
+
+	if err := apply(scope, out, tty, "", 0); err != nil {
 		log.Fatalf("%+v", err)
 	}
 }
@@ -40,18 +59,21 @@ func apply(
 	scope *Scope,
 	out io.Writer,
 	tty genericTTY,
-	filePath, filePathRaw string,
+	filePath string,
 	lineNum int,
 ) error {
 	if scope.Files == nil {
 		scope.Files = map[string]*ast.File{}
 	}
+	scope.markCaptured()
 
-	if err := scope.ConfigureTypes(filePath, lineNum); err != nil {
-		return err
-	}
+	if filePath != "" {
+		if err := scope.ConfigureTypes(filePath, lineNum); err != nil {
+			return err
+		}
 
-	displayFilePosition(out, filePathRaw, filePath, lineNum)
+		displayFilePosition(out, filePath, lineNum)
+	}
 
 	history, err := NewHistory()
 	if err != nil {
@@ -63,7 +85,17 @@ func apply(
 
 	currentPos := history.Len()
 
+	input, stopInput := readRunes(tty)
+	defer stopInput()
+	defer reportLeaks(scope, out)
+
 	line := ""
+	s := &session{scope: scope, out: out, input: input}
+	defer func() {
+		if s.traceFile != nil {
+			s.traceFile.Close()
+		}
+	}()
 	count := history.Len()
 	index := 0
 	r := rune(0)
@@ -78,11 +110,11 @@ func apply(
 
 		r = 0
 		for r == 0 {
-			var err error
-			r, err = tty.ReadRune()
-			if err != nil {
-				return err
+			in := <-input
+			if in.err != nil {
+				return in.err
 			}
+			r = in.r
 		}
 		switch r {
 		default:
@@ -151,16 +183,9 @@ func apply(
 			if line == "continue" || line == "exit" {
 				return nil
 			}
-			resp, err := scope.InterpretString(line)
-			if err != nil {
-				fmt.Fprintln(out, "Error: ", err, resp)
-			} else {
-				respStr := Highlight(fmt.Sprintf("%#v", resp))
-				fmt.Fprintf(out, "=> %s\n", respStr)
-			}
+			s.run(line)
 			history.Add(line)
-			err = history.Save()
-			if err != nil {
+			if err := history.Save(); err != nil {
 				fmt.Fprintln(out, "Error: ", err)
 			}
 
@@ -168,6 +193,10 @@ func apply(
 			currentPos = count
 			line = ""
 			index = 0
+		case 3: // Ctrl-C
+			fmt.Fprintln(out, "^C")
+			line = ""
+			index = 0
 		case 4: // Ctrl-D
 			fmt.Fprintln(out)
 			return nil
@@ -175,10 +204,398 @@ func apply(
 	}
 }
 
+// session is the state of a pry shell kept between lines.
+type session struct {
+	scope *Scope
+	out   io.Writer
+	input <-chan ttyInput
+	// explainFalse explains expressions that evaluate to false.
+	explainFalse bool
+	// traceFile is the file trace output goes to, if any.
+	traceFile io.Closer
+}
+
+// run runs a line entered at the prompt, which is either a command or Go code.
+func (s *session) run(line string) {
+	if name, args, ok := parseCommand(line); ok {
+		switch name {
+		case "engine":
+			setEngine(s.scope, s.out, args)
+		case "goroutines":
+			goroutinesCommand(s.scope, s.out, args, s.input)
+		case "trace":
+			s.traceFile = setTrace(s.scope, s.out, args, s.traceFile)
+		case "explain":
+			switch arg := args[0]; arg {
+			case "on", "off":
+				s.explainFalse = arg == "on"
+				fmt.Fprintf(s.out, "=> explain %s\n", arg)
+			default:
+				explain(s.scope, s.out, arg, s.input, false)
+			}
+		}
+		return
+	}
+	if isImport(line) {
+		importCommand(s.scope, s.out, line, s.input)
+		return
+	}
+
+	var resp interface{}
+	var err error
+	if s.explainFalse {
+		resp, err = explain(s.scope, s.out, line, s.input, true)
+	} else {
+		resp, err = evaluate(s.scope, line, s.input)
+	}
+	if err != nil {
+		fmt.Fprintln(s.out, "Error: ", err, resp)
+	} else {
+		respStr := Highlight(fmt.Sprintf("%#v", resp))
+		fmt.Fprintf(s.out, "=> %s\n", respStr)
+	}
+	reportGoroutines(s.scope, s.out)
+}
+
+// parseCommand returns the name and arguments of the command on the line, if
+// it's one. Lines are only commands if they match the grammar of the command
+// exactly, so Go code using the name of a command, like "engine := 1", is
+// still evaluated. The argument of explain is the rest of the line.
+func parseCommand(line string) (string, []string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+	name, args := fields[0], fields[1:]
+	ok := false
+	switch name {
+	case "engine":
+		// engine [<name>]
+		ok = len(args) == 0 || len(args) == 1 && token.IsIdentifier(args[0])
+	case "goroutines":
+		// goroutines [cancel <id>... | wait [<id>...]]
+		ok = len(args) == 0 || (args[0] == "cancel" && len(args) > 1 || args[0] == "wait") && areInts(args[1:])
+	case "trace":
+		// trace on [<file>] | trace off
+		ok = len(args) == 0 || args[0] == "on" && len(args) <= 2 || args[0] == "off" && len(args) == 1
+	case "explain":
+		// explain on | explain off | explain <expr>
+		rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), name))
+		args = []string{rest}
+		ok = rest == "on" || rest == "off" || isExplainExpr(rest)
+	}
+	return name, args, ok
+}
+
+// isExplainExpr reports whether src is the expression of an explain command.
+// Expressions starting with an operator that can be binary, like "= x" or
+// "- x", are left out since "explain" followed by them is Go code.
+func isExplainExpr(src string) bool {
+	if _, err := parser.ParseExpr(src); err != nil {
+		return false
+	}
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+	_, tok, _ := s.Scan()
+	return !tok.IsOperator() || tok == token.LPAREN || tok == token.LBRACK || tok == token.NOT
+}
+
+// areInts reports whether all args are integers.
+func areInts(args []string) bool {
+	for _, arg := range args {
+		if _, err := strconv.Atoi(arg); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ttyInput is a rune read from the terminal.
+type ttyInput struct {
+	r   rune
+	err error
+}
+
+// readRunes reads from tty in the background, so input like Ctrl-C can be
+// handled while an evaluation is running. A read error is delivered
+// repeatedly until stop is called.
+func readRunes(tty genericTTY) (<-chan ttyInput, func()) {
+	c := make(chan ttyInput)
+	done := make(chan struct{})
+	go func() {
+		for {
+			r, err := tty.ReadRune()
+			for {
+				select {
+				case c <- ttyInput{r, err}:
+				case <-done:
+					return
+				}
+				if err == nil {
+					break
+				}
+			}
+		}
+	}()
+	return c, func() { close(done) }
+}
+
+// evaluate runs line with the engine of the scope. Pressing Ctrl-C or
+// sending an interrupt signal cancels the evaluation. A second Ctrl-C gives up
+// on an evaluation that doesn't notice, such as one blocked in a native call.
+// Other input is discarded while the evaluation runs.
+func evaluate(scope *Scope, line string, input <-chan ttyInput) (interface{}, error) {
+	return interruptible(input, func(ctx context.Context) (interface{}, error) {
+		return scope.EvalContext(ctx, line)
+	})
+}
+
+// interruptible runs fn with a context that's cancelled by Ctrl-C or an
+// interrupt signal, as described for evaluate.
+func interruptible(input <-chan ttyInput, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals, stop := notifyInterrupt()
+	defer stop()
+
+	type result struct {
+		v   interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn(ctx)
+		done <- result{v, err}
+	}()
+
+	interrupt := func() bool {
+		if ctx.Err() != nil {
+			return true
+		}
+		cancel()
+		return false
+	}
+	for {
+		select {
+		case res := <-done:
+			return res.v, res.err
+		case in := <-input:
+			if in.err != nil {
+				// Leave the error for the prompt to report.
+				input = nil
+				cancel()
+			} else if in.r == 3 && interrupt() {
+				return nil, ErrInterrupted
+			}
+		case <-signals:
+			if interrupt() {
+				return nil, ErrInterrupted
+			}
+		}
+	}
+}
+
+// explain evaluates line with Explain and prints the diagram, only if the
+// result is false when onlyFalse is set.
+func explain(scope *Scope, out io.Writer, line string, input <-chan ttyInput, onlyFalse bool) (interface{}, error) {
+	v, err := interruptible(input, func(ctx context.Context) (interface{}, error) {
+		return scope.ExplainContext(ctx, line)
+	})
+	if err != nil {
+		if !onlyFalse {
+			fmt.Fprintln(out, "Error: ", err)
+		}
+		return nil, err
+	}
+	ex := v.(*Explanation)
+	if result, ok := ex.Result.(bool); !onlyFalse || ok && !result {
+		fmt.Fprint(out, ex)
+	}
+	return ex.Result, nil
+}
+
+// importCommand handles import declarations, making the packages available
+// at the prompt. Building a plugin can be interrupted with Ctrl-C.
+func importCommand(scope *Scope, out io.Writer, line string, input <-chan ttyInput) {
+	specs, err := parseImports(line)
+	if err != nil {
+		fmt.Fprintln(out, "Error: ", err)
+		return
+	}
+	for _, spec := range specs {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if _, err := interruptible(input, func(ctx context.Context) (interface{}, error) {
+			return scope.Import(ctx, path, name)
+		}); err != nil {
+			fmt.Fprintln(out, "Error: ", err)
+			return
+		}
+		fmt.Fprintf(out, "=> imported %s\n", path)
+	}
+}
+
+// setTrace handles the trace command. "trace on" logs executed statements to
+// the terminal, "trace on <file>" appends them to file and "trace off" stops
+// tracing. It returns the file trace output now goes to.
+func setTrace(scope *Scope, out io.Writer, args []string, file io.Closer) io.Closer {
+	if len(args) == 0 || (args[0] != "on" && args[0] != "off") || (args[0] == "off" && len(args) > 1) {
+		fmt.Fprintln(out, "Error: usage: trace on [file] | trace off")
+		return file
+	}
+	var w io.Writer = out
+	var next io.Closer
+	if len(args) == 2 {
+		f, err := os.OpenFile(args[1], os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			fmt.Fprintln(out, "Error: ", err)
+			return file
+		}
+		w, next = f, f
+	}
+	if file != nil {
+		file.Close()
+	}
+	if args[0] == "off" {
+		scope.SetTrace(nil)
+		fmt.Fprintln(out, "=> trace off")
+		return nil
+	}
+	scope.SetTrace(w)
+	if next != nil {
+		fmt.Fprintf(out, "=> tracing to %s\n", args[1])
+	} else {
+		fmt.Fprintln(out, "=> trace on")
+	}
+	return next
+}
+
+// setEngine handles the engine command. Without an argument it lists the
+// available engines, otherwise it switches the scope to the named engine.
+func setEngine(scope *Scope, out io.Writer, args []string) {
+	if len(args) == 0 {
+		current := engineName(scope.engine())
+		for _, name := range EngineNames() {
+			caret := "  "
+			if name == current {
+				caret = "=>"
+			}
+			fmt.Fprintf(out, "%s %s\n", caret, name)
+		}
+		return
+	}
+	engine, err := LookupEngine(args[0])
+	if err != nil {
+		fmt.Fprintln(out, "Error: ", err)
+		return
+	}
+	scope.Engine = engine
+	fmt.Fprintf(out, "=> using the %s engine\n", args[0])
+}
+
+// goroutinesCommand handles the goroutines command. Without arguments it lists
+// the goroutines, "cancel <id>..." cancels them and "wait [<id>...]" waits for
+// them, or for all running goroutines if no IDs are given.
+func goroutinesCommand(scope *Scope, out io.Writer, args []string, input <-chan ttyInput) {
+	if len(args) == 0 {
+		all := scope.Goroutines()
+		if len(all) == 0 {
+			fmt.Fprintln(out, "=> no goroutines")
+		}
+		for _, g := range all {
+			fmt.Fprintf(out, "%3d %-9s %s\n", g.ID, g.State(), g.Source)
+			if err := g.Err(); err != nil {
+				fmt.Fprintf(out, "    %s\n", err)
+			}
+		}
+		return
+	}
+
+	var selected []*Goroutine
+	for _, arg := range args[1:] {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			fmt.Fprintf(out, "Error: invalid goroutine id %q\n", arg)
+			return
+		}
+		g, ok := scope.Goroutine(id)
+		if !ok {
+			fmt.Fprintf(out, "Error: no goroutine %d\n", id)
+			return
+		}
+		selected = append(selected, g)
+	}
+
+	switch args[0] {
+	case "cancel":
+		if len(selected) == 0 {
+			fmt.Fprintln(out, "Error: usage: goroutines cancel <id>...")
+			return
+		}
+		for _, g := range selected {
+			g.Cancel()
+		}
+	case "wait":
+		if len(selected) == 0 {
+			for _, g := range scope.Goroutines() {
+				if g.State() == GoroutineRunning {
+					selected = append(selected, g)
+				}
+			}
+		}
+		_, err := interruptible(input, func(ctx context.Context) (interface{}, error) {
+			for _, g := range selected {
+				if err := g.Wait(ctx); err == ErrInterrupted {
+					return nil, err
+				}
+			}
+			return nil, nil
+		})
+		if err != nil {
+			fmt.Fprintln(out, "Error: ", err)
+			return
+		}
+		for _, g := range selected {
+			fmt.Fprintf(out, "=> goroutine %d %s\n", g.ID, g.State())
+		}
+	default:
+		fmt.Fprintf(out, "Error: unknown goroutines command %q\n", args[0])
+	}
+}
+
+// reportGoroutines prints the goroutines that failed since the last report,
+// so failures don't interleave with the prompt.
+func reportGoroutines(scope *Scope, out io.Writer) {
+	for _, g := range scope.failedGoroutines() {
+		fmt.Fprintf(out, "goroutine %d failed: %s\n", g.ID, g.Err())
+	}
+}
+
+// reportLeaks prints the goroutines still running when a session exits.
+func reportLeaks(scope *Scope, out io.Writer) {
+	var running []*Goroutine
+	for _, g := range scope.Goroutines() {
+		if g.State() == GoroutineRunning {
+			running = append(running, g)
+		}
+	}
+	if len(running) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "%d goroutine(s) still running:\n", len(running))
+	for _, g := range running {
+		fmt.Fprintf(out, "%3d %s (started %s ago)\n", g.ID, g.Source, time.Since(g.Started).Round(time.Millisecond))
+	}
+}
+
 func displayFilePosition(
-	out io.Writer, filePathRaw, filePath string, lineNum int,
+	out io.Writer, filePath string, lineNum int,
 ) {
-	fmt.Fprintf(out, "\nFrom %s @ line %d :\n\n", filePathRaw, lineNum)
+	fmt.Fprintf(out, "\nFrom %s @ line %d :\n\n", filePath, lineNum)
 	file, err := readFile(filePath)
 	if err != nil {
 		fmt.Fprintln(out, err)
@@ -1,12 +1,15 @@
 package pry
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -76,6 +79,130 @@ func TestCLIEditingArrows(t *testing.T) {
 	})
 }
 
+func TestCLIInterrupt(t *testing.T) {
+	t.Parallel()
+
+	env := testPryApply(t)
+	defer env.Close()
+
+	env.Write([]byte("for {}\n"))
+	// Ctrl-C
+	env.Write([]byte("\x03"))
+
+	succeedsSoon(t, func() error {
+		if !strings.Contains(env.Output(), ErrInterrupted.Error()) {
+			return errors.Errorf("expected the loop to be interrupted\nOutput:\n%s\n", env.Output())
+		}
+		return nil
+	})
+
+	env.Write([]byte("a := 10\n"))
+
+	succeedsSoon(t, func() error {
+		out, _ := env.Get("a")
+		want := 10
+		if !reflect.DeepEqual(out, want) {
+			return errors.Errorf(
+				"expected a = %d; got %d\nOutput:\n%s\n", want, out, env.Output())
+		}
+		return nil
+	})
+}
+
+func TestREPL(t *testing.T) {
+	t.Parallel()
+
+	var stdout safebuffer.Buffer
+	tty := makeTestTTY()
+	defer tty.Close()
+	scope := NewScope()
+	go apply(scope, &stdout, tty, "", 0)
+
+	tty.Write([]byte("a := 10\n"))
+
+	succeedsSoon(t, func() error {
+		out, _ := scope.Get("a")
+		want := 10
+		if !reflect.DeepEqual(out, want) {
+			return errors.Errorf(
+				"expected a = %d; got %d\nOutput:\n%s\n", want, out, stdout.String())
+		}
+		return nil
+	})
+	tty.Write([]byte("exit\n"))
+}
+
+func TestEvaluateCtrlC(t *testing.T) {
+	t.Parallel()
+
+	input := make(chan ttyInput)
+	go func() { input <- ttyInput{r: 3} }()
+	if _, err := evaluate(NewScope(), "for {}", input); err != ErrInterrupted {
+		t.Errorf("Expected %v got %v.", ErrInterrupted, err)
+	}
+}
+
+func TestGoroutinesCommand(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	if _, err := scope.InterpretString(`ch := make(chan int); go func() { <-ch }()`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var out bytes.Buffer
+	goroutinesCommand(scope, &out, nil, nil)
+	if !strings.Contains(out.String(), "1 running   go func() { <-ch }()") {
+		t.Errorf("Expected the goroutine to be listed got %q.", out.String())
+	}
+
+	out.Reset()
+	reportLeaks(scope, &out)
+	if !strings.Contains(out.String(), "1 goroutine(s) still running") {
+		t.Errorf("Expected a leak report got %q.", out.String())
+	}
+
+	out.Reset()
+	goroutinesCommand(scope, &out, []string{"cancel", "1"}, nil)
+	goroutinesCommand(scope, &out, []string{"wait", "1"}, nil)
+	if !strings.Contains(out.String(), "goroutine 1 cancelled") {
+		t.Errorf("Expected the goroutine to be cancelled got %q.", out.String())
+	}
+}
+
+func TestSetTraceFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "go-pry-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "trace.log")
+
+	scope := NewScope()
+	var out bytes.Buffer
+	file := setTrace(scope, &out, []string{"on", path}, nil)
+	if _, err := scope.InterpretString(`a := 1`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if file = setTrace(scope, &out, []string{"off"}, file); file != nil {
+		t.Errorf("Expected the trace file to be closed")
+	}
+	if _, err := scope.InterpretString(`a = 2`); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	trace, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "1:1    a := 1\n         a = 1\n"
+	if string(trace) != expected {
+		t.Errorf("Expected %q got %q.", expected, trace)
+	}
+}
+
 type testTTY struct {
 	*io.PipeReader
 	*io.PipeWriter
@@ -149,7 +276,7 @@ func main() {
 	lineNum := 2
 
 	go func() {
-		if err := apply(scope, &stdout, tty, filePath, filePath, lineNum); err != nil {
+		if err := apply(scope, &stdout, tty, filePath, lineNum); err != nil {
 			log.Fatalf("%+v", err)
 		}
 	}()
@@ -181,3 +308,82 @@ func succeedsSoon(t testing.TB, f func() error) {
 		t.Fatal(errors.Wrapf(err, "failed after 5 seconds"))
 	}
 }
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		line string
+		args []string
+		ok   bool
+	}{
+		{"engine", []string{}, true},
+		{"engine ssa", []string{"ssa"}, true},
+		{"engine := 1", nil, false},
+		{"engine = other", nil, false},
+		{"engine.Eval(x)", nil, false},
+		{"engine ssa extra", nil, false},
+		{"goroutines", []string{}, true},
+		{"goroutines cancel 1 2", []string{"cancel", "1", "2"}, true},
+		{"goroutines wait", []string{"wait"}, true},
+		{"goroutines := 3", nil, false},
+		{"goroutines = append(goroutines, 1)", nil, false},
+		{"goroutines cancel", nil, false},
+		{"trace on", []string{"on"}, true},
+		{"trace on /tmp/trace.log", []string{"on", "/tmp/trace.log"}, true},
+		{"trace off", []string{"off"}, true},
+		{"trace := 1", nil, false},
+		{"trace off now", nil, false},
+		{"explain on", []string{"on"}, true},
+		{"explain a > 1 && b", []string{"a > 1 && b"}, true},
+		{"explain !ok", []string{"!ok"}, true},
+		{"explain := true", nil, false},
+		{"explain = false", nil, false},
+		{"explain - x", nil, false},
+		{"explain", nil, false},
+	}
+	for _, c := range cases {
+		_, args, ok := parseCommand(c.line)
+		if ok != c.ok || ok && !reflect.DeepEqual(args, c.args) {
+			t.Errorf("parseCommand(%q) = %q, %t, expected %q, %t", c.line, args, ok, c.args, c.ok)
+		}
+	}
+}
+
+// Go code starting with the name of a command is still evaluated.
+func TestSessionCommandNames(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	s := &session{scope: NewScope(), out: &out}
+	for _, line := range []string{
+		"engine := 1",
+		"goroutines := []int{}",
+		"goroutines = append(goroutines, engine)",
+		"trace := 2",
+		"explain := true",
+		"explain = trace > engine",
+	} {
+		s.run(line)
+	}
+	if strings.Contains(out.String(), "Error") {
+		t.Fatalf("Expected the lines to be evaluated, got %q.", out.String())
+	}
+	expected := map[string]interface{}{
+		"engine":     1,
+		"goroutines": []int{1},
+		"trace":      2,
+		"explain":    true,
+	}
+	for name, value := range expected {
+		if out, _ := s.scope.Get(name); !reflect.DeepEqual(out, value) {
+			t.Errorf("Expected %s = %#v got %#v.", name, value, out)
+		}
+	}
+
+	out.Reset()
+	s.run("engine")
+	if !strings.Contains(out.String(), "=> reflect") {
+		t.Errorf("Expected the engines to be listed got %q.", out.String())
+	}
+}
@@ -882,7 +882,11 @@ func (scope *Scope) ComputeUnaryOp(xI interface{}, op token.Token) (interface{},
 					return nil, ErrChanRecvInSelect
 				}
 			} else {
-				v, ok = reflect.ValueOf(xI).Recv()
+				var err error
+				v, ok, err = recv(scope.context(), scope.limits(), reflect.ValueOf(xI))
+				if err != nil {
+					return nil, err
+				}
 			}
 			if !ok {
 				return nil, ErrChanRecvFailed
@@ -0,0 +1,129 @@
+package pry
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*registeredPackage{}
+)
+
+// registeredPackage is a package registered with RegisterPackage. vars
+// flags the symbols that are pointers to variables.
+type registeredPackage struct {
+	name    string
+	symbols map[string]interface{}
+	vars    map[string]bool
+}
+
+// register adds the symbols to the package with the import path in packages,
+// naming it name unless it's empty.
+func register(packages map[string]*registeredPackage, importPath, name string, symbols map[string]interface{}) {
+	pkg, ok := packages[importPath]
+	if !ok {
+		pkg = &registeredPackage{symbols: map[string]interface{}{}, vars: map[string]bool{}}
+		packages[importPath] = pkg
+	}
+	if name != "" {
+		pkg.name = name
+	}
+	for name, v := range symbols {
+		pkg.symbols[name] = v
+		_, isVar := varPointer(v)
+		pkg.vars[name] = isVar
+	}
+}
+
+// RegisterPackage makes the exported symbols of the package with the import
+// path and name available to interpreters, keyed by name. Functions and
+// constants are registered by value, variables as pointers to them and types
+// as a reflect.Type. Registering a path again adds to its symbols. It's meant
+// to be called from init by symbol tables generated with go-pry extract.
+func RegisterPackage(importPath, name string, symbols map[string]interface{}) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	register(registry, importPath, name, symbols)
+}
+
+// RegisterType adds the type of v to the registered package with the import
+// path under name. v is usually a pointer to the type, such as (*T)(nil), so
+// interface types can be registered too. The package is only named by
+// RegisterPackage.
+func RegisterType(importPath, name string, v interface{}) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		t = t.Elem()
+	}
+	RegisterPackage(importPath, "", map[string]interface{}{name: t})
+}
+
+// RegisterValue adds a function, constant or pointer to a variable to the
+// registered package with the import path under name. The package is only
+// named by RegisterPackage.
+func RegisterValue(importPath, name string, v interface{}) {
+	RegisterPackage(importPath, "", map[string]interface{}{name: v})
+}
+
+// RegisteredPackages returns the import paths of the registered packages.
+func RegisteredPackages() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	var paths []string
+	for p := range registry {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// LookupPackage returns the registered package with the import path. Its
+// name is empty if it was only registered with RegisterType and
+// RegisterValue.
+func LookupPackage(importPath string) (Package, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	pkg, ok := registry[importPath]
+	if !ok {
+		return Package{}, false
+	}
+	return pkg.lookup(importPath), true
+}
+
+// lookup returns a copy of the package registered under the import path.
+func (pkg *registeredPackage) lookup(importPath string) Package {
+	functions := make(map[string]interface{}, len(pkg.symbols))
+	vars := map[string]bool{}
+	for name, v := range pkg.symbols {
+		functions[name] = v
+		if pkg.vars[name] {
+			vars[name] = true
+		}
+	}
+	return Package{Name: pkg.name, Path: importPath, Functions: functions, Vars: vars}
+}
+
+// Capture returns the scope of a pry statement injected by go-pry. It binds
+// the package level identifiers registered for pkgPath, the registered
+// packages of imports, which maps the names of the imports of the file to
+// their paths, and vals, the local variables of the statement, which shadow
+// the others.
+func Capture(pkgPath string, imports map[string]string, vals map[string]interface{}) *Scope {
+	scope := &Scope{Vals: map[string]interface{}{}}
+	if pkg, ok := LookupPackage(pkgPath); ok {
+		for name, v := range pkg.Functions {
+			scope.Vals[name] = v
+		}
+	}
+	for name, importPath := range imports {
+		if pkg, ok := LookupPackage(importPath); ok {
+			scope.Vals[name] = pkg
+		}
+	}
+	for name, v := range vals {
+		scope.Vals[name] = v
+	}
+	return scope
+}
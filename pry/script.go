@@ -0,0 +1,188 @@
+package pry
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// RunScript interprets src, the source of a file in package main, and runs
+// its init functions and main in scope. The imports of the file are bound from
+// the registered packages. A leading #! line is ignored so scripts can be
+// made executable. filename is only used in error messages.
+//
+// Types, constants, variables and functions can be declared in any order,
+// although variables are initialized in source order. Methods aren't
+// supported.
+func (scope *Scope) RunScript(ctx context.Context, filename string, src []byte) (err error) {
+	_, end := scope.beginEval(ctx)
+	defer end()
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("running %s: %s", filename, fmt.Sprint(r))
+		}
+	}()
+
+	if len(src) > 1 && src[0] == '#' && src[1] == '!' {
+		// Keep the offsets of the file by commenting the line out.
+		src = append([]byte("//"), src[2:]...)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return err
+	}
+	if file.Name.Name != "main" {
+		return errors.Errorf("%s: expected package main, found %s", filename, file.Name.Name)
+	}
+	defer scope.withSource(&source{src: string(src)})()
+
+	position := func(n ast.Node) string {
+		return fset.Position(n.Pos()).String()
+	}
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return err
+		}
+		pkg, ok := LookupPackage(path)
+		if !ok {
+			return errors.Errorf("%s: no symbol table for package %q", position(imp), path)
+		}
+		name := pkg.Name
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		switch name {
+		case "_":
+			continue
+		case ".":
+			return errors.Errorf("%s: dot imports are not supported", position(imp))
+		}
+		scope.Lock()
+		scope.bind(name, pkg)
+		scope.Unlock()
+	}
+
+	var inits []*Func
+	var values []*ast.GenDecl
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil {
+				return errors.Errorf("%s: methods are not supported", position(decl))
+			}
+			fn := &Func{Def: &ast.FuncLit{Type: decl.Type, Body: decl.Body}, source: scope.currentSource()}
+			if decl.Name.Name == "init" {
+				inits = append(inits, fn)
+				continue
+			}
+			scope.Define(decl.Name, fn)
+		case *ast.GenDecl:
+			switch decl.Tok {
+			case token.TYPE:
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					typ, err := scope.Interpret(spec.Type)
+					if err != nil {
+						return errors.Wrapf(err, "%s", position(spec))
+					}
+					scope.Define(spec.Name, typ)
+				}
+			case token.CONST, token.VAR:
+				values = append(values, decl)
+			}
+		}
+	}
+	for _, decl := range values {
+		if err := scope.declareValues(decl); err != nil {
+			return errors.Wrapf(err, "%s", position(decl))
+		}
+	}
+
+	for _, fn := range inits {
+		if _, err := scope.CallFunc(fn, nil); err != nil {
+			return err
+		}
+	}
+	main, ok := scope.Get("main")
+	if _, isFunc := main.(*Func); !ok || !isFunc {
+		return errors.Errorf("%s: function main is undeclared in the main package", filename)
+	}
+	_, err = scope.CallFunc(main, nil)
+	return err
+}
+
+// declareValues declares the constants or variables of a package level
+// declaration. Constants can use iota and repeat the previous expressions
+// when their values are omitted.
+func (scope *Scope) declareValues(decl *ast.GenDecl) error {
+	var prev *ast.ValueSpec
+	for i, spec := range decl.Specs {
+		spec := spec.(*ast.ValueSpec)
+		exprs, typExpr := spec.Values, spec.Type
+		if decl.Tok == token.CONST && len(exprs) == 0 && prev != nil {
+			exprs, typExpr = prev.Values, prev.Type
+		}
+		prev = &ast.ValueSpec{Values: exprs, Type: typExpr}
+
+		var typ reflect.Type
+		if typExpr != nil {
+			t, err := scope.Interpret(typExpr)
+			if err != nil {
+				return err
+			}
+			var isType bool
+			if typ, isType = t.(reflect.Type); !isType {
+				return errors.Errorf("%s is not a type", scope.Render(typExpr))
+			}
+		}
+
+		child := scope.NewChild()
+		if decl.Tok == token.CONST {
+			child.Define(ast.NewIdent("iota"), i)
+		}
+		vals := make([]interface{}, len(spec.Names))
+		if len(exprs) == 1 && len(spec.Names) > 1 {
+			v, err := child.Interpret(exprs[0])
+			if err != nil {
+				return err
+			}
+			multi, ok := v.([]interface{})
+			if !ok || len(multi) != len(vals) {
+				return errors.Errorf("assignment mismatch: %d variables but %s returns %#v", len(vals), scope.Render(exprs[0]), v)
+			}
+			copy(vals, multi)
+		} else {
+			for j := range exprs {
+				if j >= len(vals) {
+					return errors.Errorf("extra init expr %s", scope.Render(exprs[j]))
+				}
+				v, err := child.Interpret(exprs[j])
+				if err != nil {
+					return err
+				}
+				vals[j] = v
+			}
+		}
+		for j, name := range spec.Names {
+			v := vals[j]
+			switch {
+			case typ != nil && v == nil:
+				v = reflect.Zero(typ).Interface()
+			case typ != nil:
+				v = reflect.ValueOf(v).Convert(typ).Interface()
+			}
+			if name.Name != "_" {
+				scope.Define(name, v)
+			}
+		}
+	}
+	return nil
+}
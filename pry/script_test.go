@@ -0,0 +1,78 @@
+package pry
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testScript = `#!/usr/bin/env go-pry
+package main
+
+import (
+	"fmt"
+	et "github.com/d4l3k/go-pry/pry/embedtest"
+)
+
+type point struct {
+	X, Y int
+}
+
+const (
+	a = iota * 10
+	b
+	c
+)
+
+var total = fib(10) + b
+
+var result string
+
+func init() {
+	total++
+}
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+func main() {
+	p := point{X: 1, Y: c}
+	result = fmt.Sprintf("%d %d %d", total, p.Y, et.Double(p.X))
+}
+`
+
+func TestRunScript(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope()
+	if err := scope.RunScript(context.Background(), "script.go", []byte(testScript)); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	out, _ := scope.Get("result")
+	expected := "66 20 2"
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("Expected %#v got %#v.", expected, out)
+	}
+}
+
+func TestRunScriptErrors(t *testing.T) {
+	t.Parallel()
+
+	for src, expected := range map[string]string{
+		"package foo":                             "expected package main",
+		"package main\nimport \"unknown/pkg\"":    `2:8: no symbol table for package "unknown/pkg"`,
+		"package main\nfunc (p T) M() {}":         "2:1: methods are not supported",
+		"package main\nvar x = 1":                 "function main is undeclared",
+		"package main\nfunc main() { missing() }": "can't find EXPR missing",
+	} {
+		err := NewScope().RunScript(context.Background(), "script.go", []byte(src))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: Expected an error containing %q got %v.", src, expected, err)
+		}
+	}
+}
@@ -0,0 +1,1500 @@
+package pry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/ssa"
+)
+
+// SSAEngine evaluates input by building it into SSA form together with the
+// type checked package of the scope and interpreting the instructions.
+// Variables captured from the program are bound to their storage in the
+// scope, and functions of imported packages as well as methods are called
+// natively.
+type SSAEngine struct{}
+
+const (
+	ssaEvalFunc   = "__pryEval"
+	ssaBindFunc   = "__pryBind"
+	ssaDefineFunc = "__pryDefine"
+)
+
+var errNilDereference = errors.New("runtime error: invalid memory address or nil pointer dereference")
+
+// Eval implements Engine.
+func (SSAEngine) Eval(ctx context.Context, scope *Scope, src string) (v interface{}, err error) {
+	_, end := scope.beginEval(ctx)
+	defer end()
+	defer func() {
+		if r := recover(); r != nil {
+			v, err = nil, panicError(r)
+		}
+	}()
+
+	node, _, err := scope.ParseString(src)
+	if err != nil {
+		return nil, err
+	}
+	if errs := scope.CheckStatement(node); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	in, err := scope.buildSSA(node)
+	if err != nil {
+		return nil, err
+	}
+	res := in.callFunction(in.eval, nil, nil, nil)
+	if r := res[0]; r.IsValid() && !r.IsNil() {
+		return r.Elem().Interface(), nil
+	}
+	return nil, nil
+}
+
+// panicError converts a value recovered while interpreting into an error.
+func panicError(r interface{}) error {
+	switch r := r.(type) {
+	case runtimePanic:
+		return r.err
+	case ssaPanic:
+		return errors.Errorf("panic: %v", r.value)
+	}
+	return errors.Errorf("panic: %v", r)
+}
+
+// typesPackage returns the package of the last successful type check.
+func (scope *Scope) typesPackage() *types.Package {
+	for ; scope != nil; scope = scope.Parent {
+		scope.Lock()
+		pkg := scope.pkg
+		scope.Unlock()
+		if pkg != nil {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// pryFile returns the file containing the pry statement.
+func (scope *Scope) pryFile() *ast.File {
+	return scope.Files[scope.path]
+}
+
+// nodeStmts returns the statements of a parsed REPL line.
+func nodeStmts(node ast.Node) []ast.Stmt {
+	switch n := node.(type) {
+	case *ast.BlockStmt:
+		return n.List
+	case ast.Stmt:
+		return []ast.Stmt{n}
+	case ast.Expr:
+		return []ast.Stmt{&ast.ExprStmt{X: n}}
+	}
+	return nil
+}
+
+// capturedVars returns the local variables of the program node refers to.
+func capturedVars(node ast.Node, info *types.Info) []*types.Var {
+	declared := map[types.Object]bool{}
+	seen := map[types.Object]bool{}
+	var used []*types.Var
+	ast.Inspect(node, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if obj := info.Defs[ident]; obj != nil {
+			declared[obj] = true
+		}
+		v, ok := info.Uses[ident].(*types.Var)
+		if !ok || v.IsField() || seen[v] || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			return true
+		}
+		seen[v] = true
+		used = append(used, v)
+		return true
+	})
+	var captured []*types.Var
+	for _, v := range used {
+		if !declared[v] {
+			captured = append(captured, v)
+		}
+	}
+	return captured
+}
+
+// topLevelDefs returns the variables declared at the top level of node. They
+// are added to the scope after evaluation.
+func topLevelDefs(node ast.Node, info *types.Info) []*ast.Ident {
+	var idents []*ast.Ident
+	add := func(ident *ast.Ident) {
+		if ident.Name != "_" && info.Defs[ident] != nil {
+			idents = append(idents, ident)
+		}
+	}
+	for _, stmt := range nodeStmts(node) {
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE {
+				continue
+			}
+			for _, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					add(ident)
+				}
+			}
+		case *ast.DeclStmt:
+			decl, ok := s.Decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				for _, ident := range spec.(*ast.ValueSpec).Names {
+					add(ident)
+				}
+			}
+		}
+	}
+	return idents
+}
+
+// singleValue returns whether expr evaluates to exactly one value.
+func singleValue(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || tv.IsType() || tv.Type == nil {
+		return false
+	}
+	_, isTuple := tv.Type.(*types.Tuple)
+	return !isTuple
+}
+
+// ssaSource renders node as the body of a function in the package of the
+// scope. Captured variables are declared at the top of the function and
+// passed to ssaBindFunc, so they live in memory the interpreter can bind to
+// the scope, and variables declared by node are passed to ssaDefineFunc.
+func (scope *Scope) ssaSource(node ast.Node, info *types.Info, captured []*types.Var) string {
+	pkg := scope.typesPackage()
+	imports := map[string]string{}
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "package %s\n\n", pkg.Name())
+	if file := scope.pryFile(); file != nil {
+		for _, spec := range file.Imports {
+			fmt.Fprintf(&header, "import %s\n", scope.Render(spec))
+			path, _ := strconv.Unquote(spec.Path.Value)
+			if spec.Name != nil {
+				imports[path] = spec.Name.Name
+				continue
+			}
+			for _, imp := range pkg.Imports() {
+				if imp.Path() == path {
+					imports[path] = imp.Name()
+				}
+			}
+		}
+	}
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		name, ok := imports[p.Path()]
+		if !ok || name == "_" {
+			name = fmt.Sprintf("__pry%d", len(imports))
+			imports[p.Path()] = name
+			fmt.Fprintf(&header, "import %s %q\n", name, p.Path())
+		}
+		if name == "." {
+			return ""
+		}
+		return name
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "\nfunc %s(...interface{})\n", ssaBindFunc)
+	fmt.Fprintf(&body, "func %s(string, interface{})\n\n", ssaDefineFunc)
+	fmt.Fprintf(&body, "func %s() interface{} {\n", ssaEvalFunc)
+	var refs []string
+	for _, v := range captured {
+		fmt.Fprintf(&body, "var %s %s\n", v.Name(), types.TypeString(v.Type(), qualifier))
+		refs = append(refs, "&"+v.Name())
+	}
+	if len(refs) > 0 {
+		fmt.Fprintf(&body, "%s(%s)\n", ssaBindFunc, strings.Join(refs, ", "))
+	}
+	stmts := nodeStmts(node)
+	var last ast.Expr
+	if n := len(stmts); n > 0 {
+		if s, ok := stmts[n-1].(*ast.ExprStmt); ok && singleValue(info, s.X) {
+			last = s.X
+			stmts = stmts[:n-1]
+		}
+	}
+	for _, stmt := range stmts {
+		body.WriteString(scope.Render(stmt))
+		body.WriteString("\n")
+	}
+	for _, ident := range topLevelDefs(node, info) {
+		fmt.Fprintf(&body, "%s(%q, &%s)\n", ssaDefineFunc, ident.Name, ident.Name)
+	}
+	if last != nil {
+		fmt.Fprintf(&body, "return %s\n}\n", scope.Render(last))
+	} else {
+		body.WriteString("return nil\n}\n")
+	}
+	return header.String() + body.String()
+}
+
+// buildSSA type checks node as part of the package of the scope and builds
+// the package into SSA form.
+func (scope *Scope) buildSSA(node ast.Node) (*ssaInterp, error) {
+	info, pkg := scope.typeInfo(), scope.typesPackage()
+	if info == nil || pkg == nil {
+		return nil, errors.New("the ssa engine requires a type checked scope")
+	}
+	captured := capturedVars(node, info)
+	src := scope.ssaSource(node, info, captured)
+
+	dir := filepath.Dir(scope.path)
+	f, err := parser.ParseFile(scope.fset, filepath.Join(dir, ".eval.gopry"), src, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing generated source %q", src)
+	}
+	files := []*ast.File{f}
+	for _, file := range scope.Files {
+		files = append(files, file)
+	}
+	var errs []error
+	conf := types.Config{
+		FakeImportC: true,
+		Importer:    scope.config.Importer,
+		Error: func(err error) {
+			if !unusedError(err) {
+				errs = append(errs, err)
+			}
+		},
+	}
+	checked := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Scopes:     map[ast.Node]*types.Scope{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+	tpkg, _ := conf.Check(dir, scope.fset, files, checked)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	prog := ssa.NewProgram(scope.fset, 0)
+	created := map[*types.Package]bool{tpkg: true}
+	var create func(p *types.Package)
+	create = func(p *types.Package) {
+		for _, imp := range p.Imports() {
+			if !created[imp] {
+				created[imp] = true
+				create(imp)
+				prog.CreatePackage(imp, nil, nil, true)
+			}
+		}
+	}
+	create(tpkg)
+	ssaPkg := prog.CreatePackage(tpkg, files, checked, false)
+	ssaPkg.Build()
+
+	in := &ssaInterp{
+		scope:   scope,
+		pkg:     ssaPkg,
+		eval:    ssaPkg.Func(ssaEvalFunc),
+		bound:   map[token.Pos]reflect.Value{},
+		goStmts: map[token.Pos]string{},
+		mu:      &sync.Mutex{},
+		types:   map[types.Type]reflect.Type{},
+		globals: map[*ssa.Global]reflect.Value{},
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if stmt, ok := n.(*ast.GoStmt); ok {
+			in.goStmts[stmt.Go] = goSource(stmt)
+		}
+		return true
+	})
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != ssaEvalFunc {
+			continue
+		}
+		for i, v := range captured {
+			spec := fn.Body.List[i].(*ast.DeclStmt).Decl.(*ast.GenDecl).Specs[0].(*ast.ValueSpec)
+			ptr, err := scope.boundStorage(v.Name(), in.typeOf(v.Type()))
+			if err != nil {
+				return nil, err
+			}
+			in.bound[spec.Names[0].Pos()] = ptr
+		}
+	}
+	return in, nil
+}
+
+// boundStorage returns a pointer to the storage of the scope variable name
+// with type rType. Variables stored with a different type are moved into new
+// storage of the static type.
+func (scope *Scope) boundStorage(name string, rType reflect.Type) (reflect.Value, error) {
+	v, ok := scope.storage(name)
+	if !ok {
+		return reflect.Value{}, errors.Errorf("%s is not available in the scope", name)
+	}
+	if v.Type() == rType {
+		return v.Addr(), nil
+	}
+	v = ssaFit(v, rType)
+	if !v.Type().AssignableTo(rType) {
+		return reflect.Value{}, errors.Errorf("%s is stored as %s, not %s", name, v.Type(), rType)
+	}
+	ptr := reflect.New(rType)
+	ptr.Elem().Set(v)
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		_, ok := s.Vals[name]
+		if ok {
+			s.Vals[name] = ptr.Interface()
+		}
+		s.Unlock()
+		if ok {
+			break
+		}
+	}
+	return ptr, nil
+}
+
+// ssaInterp interprets the SSA form of a package.
+type ssaInterp struct {
+	scope *Scope
+	pkg   *ssa.Package
+	eval  *ssa.Function
+	// bound maps the positions of captured variables to their storage.
+	bound map[token.Pos]reflect.Value
+	// goStmts maps the positions of the go statements in the input to their
+	// source.
+	goStmts map[token.Pos]string
+
+	// mu guards the caches, which are shared with forks.
+	mu      *sync.Mutex
+	types   map[types.Type]reflect.Type
+	globals map[*ssa.Global]reflect.Value
+}
+
+// fork returns an interpreter sharing the state of in that evaluates under
+// scope, which is used to give goroutines their own context.
+func (in *ssaInterp) fork(scope *Scope) *ssaInterp {
+	forked := *in
+	forked.scope = scope
+	return &forked
+}
+
+// tuple holds the results of instructions with multiple values.
+type tuple []reflect.Value
+
+// ssaPanic is a value passed to panic by interpreted code.
+type ssaPanic struct {
+	value interface{}
+}
+
+// ssaIter is the state of a range loop over a map or string.
+type ssaIter struct {
+	x    reflect.Value
+	keys []reflect.Value
+	i    int
+}
+
+// ssaFrame is the activation record of an interpreted function.
+type ssaFrame struct {
+	in  *ssaInterp
+	fn  *ssa.Function
+	env map[ssa.Value]reflect.Value
+	// closures holds the bindings of closures created by the frame, so they
+	// can be called without going through reflect.MakeFunc.
+	closures    map[ssa.Value][]reflect.Value
+	block, prev *ssa.BasicBlock
+	results     []reflect.Value
+
+	// caller is set for deferred calls, so recover can find the panic.
+	caller     *ssaFrame
+	defers     []func()
+	panicking  bool
+	panicValue interface{}
+}
+
+// checkpoint aborts the evaluation if it has been cancelled or ran out of
+// steps.
+func (in *ssaInterp) checkpoint() {
+	if err := in.scope.checkpoint(); err != nil {
+		throw(err)
+	}
+}
+
+// checkSize aborts the evaluation if a collection of size n exceeds the
+// limits.
+func (in *ssaInterp) checkSize(n int) {
+	if err := in.scope.limits().checkSize(n); err != nil {
+		throw(err)
+	}
+}
+
+func (in *ssaInterp) typeOf(t types.Type) reflect.Type {
+	in.mu.Lock()
+	rType, ok := in.types[t]
+	in.mu.Unlock()
+	if ok {
+		return rType
+	}
+	rType, ok = in.scope.ReflectType(t)
+	if !ok {
+		throw(errors.Errorf("type %s has no runtime representation", t))
+	}
+	in.mu.Lock()
+	in.types[t] = rType
+	in.mu.Unlock()
+	return rType
+}
+
+// native returns the compiled version of fn, if there is one in scope.
+func (in *ssaInterp) native(fn *ssa.Function) (reflect.Value, bool) {
+	if fn.Parent() != nil || fn.Pkg == nil || fn.Synthetic != "" {
+		return reflect.Value{}, false
+	}
+	var v interface{}
+	var ok bool
+	if fn.Pkg == in.pkg {
+		v, ok = in.scope.Get(fn.Name())
+	} else if pkg, isPkg := in.scope.lookupPackage(fn.Pkg.Pkg); isPkg {
+		v, ok = pkg.Get(fn.Name())
+	}
+	rv := reflect.ValueOf(v)
+	if !ok || rv.Kind() != reflect.Func {
+		return reflect.Value{}, false
+	}
+	if rType, known := in.scope.ReflectType(fn.Signature); known && rType != rv.Type() {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+// callFunction calls fn, natively if possible.
+func (in *ssaInterp) callFunction(fn *ssa.Function, args, bindings []reflect.Value, caller *ssaFrame) []reflect.Value {
+	if fn.Pkg == in.pkg && fn.Parent() == nil {
+		switch fn.Name() {
+		case ssaBindFunc:
+			return nil
+		case ssaDefineFunc:
+			in.define(args[0].String(), args[1].Elem())
+			return nil
+		}
+	}
+	if fn.Signature.Recv() != nil && len(args) > 0 {
+		if m := args[0].MethodByName(fn.Name()); m.IsValid() {
+			return in.callValue(m, args[1:])
+		}
+	} else if f, ok := in.native(fn); ok {
+		return in.callValue(f, args)
+	}
+	return in.call(fn, args, bindings, caller)
+}
+
+// define adds a variable declared by the evaluated input to the scope.
+func (in *ssaInterp) define(name string, ptr reflect.Value) {
+	in.scope.Lock()
+	defer in.scope.Unlock()
+	in.scope.bind(name, ptr.Interface())
+}
+
+// callValue calls the function value f with args converted to its parameter
+// types.
+func (in *ssaInterp) callValue(f reflect.Value, args []reflect.Value) []reflect.Value {
+	in.checkpoint()
+	if f.Kind() == reflect.Interface {
+		f = f.Elem()
+	}
+	if !f.IsValid() || f.IsNil() {
+		throw(errNilDereference)
+	}
+	t := f.Type()
+	vals := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		vals[i] = ssaFit(arg, t.In(i))
+	}
+	if t.IsVariadic() {
+		return f.CallSlice(vals)
+	}
+	return f.Call(vals)
+}
+
+// funcValue returns fn as a value that can be passed to native code.
+func (in *ssaInterp) funcValue(fn *ssa.Function) reflect.Value {
+	if f, ok := in.native(fn); ok {
+		return f
+	}
+	return in.makeFunc(fn, nil)
+}
+
+func (in *ssaInterp) makeFunc(fn *ssa.Function, bindings []reflect.Value) reflect.Value {
+	t := in.typeOf(fn.Signature)
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		res := in.callFunction(fn, args, bindings, nil)
+		for i := range res {
+			res[i] = ssaFit(res[i], t.Out(i))
+		}
+		return res
+	})
+}
+
+// call interprets the body of fn.
+func (in *ssaInterp) call(fn *ssa.Function, args, bindings []reflect.Value, caller *ssaFrame) []reflect.Value {
+	in.checkpoint()
+	if len(fn.Blocks) == 0 {
+		throw(errors.Errorf("function %s is not available", fn))
+	}
+	fr := &ssaFrame{
+		in:       in,
+		fn:       fn,
+		caller:   caller,
+		env:      map[ssa.Value]reflect.Value{},
+		closures: map[ssa.Value][]reflect.Value{},
+		block:    fn.Blocks[0],
+	}
+	for i, p := range fn.Params {
+		fr.env[p] = args[i]
+	}
+	for i, fv := range fn.FreeVars {
+		fr.env[fv] = bindings[i]
+	}
+	for !fr.runRecovering() {
+		fr.prev, fr.block = nil, fn.Recover
+	}
+	return fr.results
+}
+
+func (in *ssaInterp) global(g *ssa.Global) reflect.Value {
+	in.mu.Lock()
+	ptr, ok := in.globals[g]
+	in.mu.Unlock()
+	if ok {
+		return ptr
+	}
+	t := in.typeOf(g.Type().(*types.Pointer).Elem())
+	switch {
+	case g.Pkg == in.pkg:
+		v, ok := in.scope.storage(g.Name())
+		if !ok || v.Type() != t {
+			throw(errors.Errorf("package variable %s is not available", g.Name()))
+		}
+		ptr = v.Addr()
+	case g.Pkg.Pkg.Scope().Lookup(g.Name()) == nil:
+		// Synthetic globals like init guards are private to the program.
+		ptr = reflect.New(t)
+	default:
+		pkg, _ := in.scope.lookupPackage(g.Pkg.Pkg)
+		if v, ok := pkg.Var(g.Name()); ok && v.Type() == reflect.PtrTo(t) {
+			ptr = v
+			break
+		}
+		v, ok := pkg.Get(g.Name())
+		if !ok {
+			throw(errors.Errorf("package variable %s is not available", g))
+		}
+		ptr = reflect.New(t)
+		ptr.Elem().Set(ssaFit(reflect.ValueOf(v), t))
+	}
+	in.mu.Lock()
+	in.globals[g] = ptr
+	in.mu.Unlock()
+	return ptr
+}
+
+func (in *ssaInterp) constant(c *ssa.Const) reflect.Value {
+	t := in.typeOf(c.Type())
+	if c.Value == nil {
+		return reflect.Zero(t)
+	}
+	v, err := constantToValue(c.Value, t)
+	if err != nil {
+		throw(err)
+	}
+	return ssaFit(reflect.ValueOf(v), t)
+}
+
+// runRecovering runs the frame until it returns. It returns false if a panic
+// was recovered by a deferred call, in which case execution resumes at the
+// Recover block.
+func (fr *ssaFrame) runRecovering() (done bool) {
+	panicked := true
+	defer func() {
+		if !panicked {
+			return
+		}
+		r := recover()
+		fr.panicking, fr.panicValue = true, r
+		fr.runDefers()
+		if fr.panicking {
+			panic(r)
+		}
+		if fr.fn.Recover == nil {
+			fr.results = nil
+			for i := 0; i < fr.fn.Signature.Results().Len(); i++ {
+				fr.results = append(fr.results, reflect.Zero(fr.in.typeOf(fr.fn.Signature.Results().At(i).Type())))
+			}
+			done = true
+		}
+	}()
+	for fr.block != nil {
+		fr.runBlock()
+	}
+	panicked = false
+	return true
+}
+
+func (fr *ssaFrame) runDefers() {
+	for len(fr.defers) > 0 {
+		d := fr.defers[len(fr.defers)-1]
+		fr.defers = fr.defers[:len(fr.defers)-1]
+		d()
+	}
+}
+
+func (fr *ssaFrame) get(v ssa.Value) reflect.Value {
+	switch v := v.(type) {
+	case *ssa.Const:
+		return fr.in.constant(v)
+	case *ssa.Global:
+		return fr.in.global(v)
+	case *ssa.Function:
+		return fr.in.funcValue(v)
+	}
+	r, ok := fr.env[v]
+	if !ok {
+		throw(errors.Errorf("no value for %s", v.Name()))
+	}
+	return r
+}
+
+func (fr *ssaFrame) set(v ssa.Value, res []reflect.Value) {
+	switch t := v.Type().(type) {
+	case *types.Tuple:
+		if t.Len() == 0 {
+			return
+		}
+		out := make(tuple, len(res))
+		for i := range res {
+			out[i] = ssaFit(res[i], fr.in.typeOf(t.At(i).Type()))
+		}
+		fr.env[v] = reflect.ValueOf(out)
+	default:
+		fr.env[v] = ssaFit(res[0], fr.in.typeOf(t))
+	}
+}
+
+func (fr *ssaFrame) runBlock() {
+	b := fr.block
+	var phis []reflect.Value
+	n := 0
+	for ; n < len(b.Instrs); n++ {
+		phi, ok := b.Instrs[n].(*ssa.Phi)
+		if !ok {
+			break
+		}
+		pred := -1
+		for i, p := range b.Preds {
+			if p == fr.prev {
+				pred = i
+			}
+		}
+		phis = append(phis, fr.get(phi.Edges[pred]))
+	}
+	for i, v := range phis {
+		fr.env[b.Instrs[i].(*ssa.Phi)] = v
+	}
+	for _, instr := range b.Instrs[n:] {
+		if fr.step(instr) {
+			return
+		}
+	}
+}
+
+// step executes instr and returns whether it transferred control.
+func (fr *ssaFrame) step(instr ssa.Instruction) bool {
+	switch instr := instr.(type) {
+	case *ssa.DebugRef:
+
+	case *ssa.Alloc:
+		if ptr, ok := fr.in.bound[instr.Pos()]; ok && fr.fn == fr.in.eval {
+			fr.env[instr] = ptr
+		} else {
+			t := fr.in.typeOf(instr.Type().(*types.Pointer).Elem())
+			// Arrays and make with a constant size are allocated here.
+			if t.Kind() == reflect.Array {
+				fr.in.checkSize(arraySize(t.Len(), t.Elem()))
+			}
+			fr.env[instr] = reflect.New(t)
+		}
+
+	case *ssa.BinOp:
+		fr.env[instr] = ssaBinOp(instr.Op, fr.get(instr.X), fr.get(instr.Y))
+
+	case *ssa.UnOp:
+		fr.env[instr] = fr.unOp(instr)
+
+	case *ssa.Call:
+		fr.set(instr, fr.prepareCall(instr.Common())(nil))
+
+	case *ssa.ChangeType, *ssa.ChangeInterface, *ssa.MakeInterface:
+		fr.env[instr.(ssa.Value)] = ssaFit(fr.get(*instr.Operands(nil)[0]), fr.in.typeOf(instr.(ssa.Value).Type()))
+
+	case *ssa.Convert:
+		fr.env[instr] = fr.get(instr.X).Convert(fr.in.typeOf(instr.Type()))
+
+	case *ssa.Extract:
+		fr.env[instr] = fr.get(instr.Tuple).Interface().(tuple)[instr.Index]
+
+	case *ssa.Field:
+		fr.env[instr] = ssaField(fr.get(instr.X), instr.Field)
+
+	case *ssa.FieldAddr:
+		ptr := fr.get(instr.X)
+		if ptr.IsNil() {
+			throw(errNilDereference)
+		}
+		fr.env[instr] = ssaField(ptr.Elem(), instr.Field).Addr()
+
+	case *ssa.Index:
+		x := fr.get(instr.X)
+		i := ssaInt(fr.get(instr.Index))
+		if err := checkIndex(i, x.Len()); err != nil {
+			throw(err)
+		}
+		fr.env[instr] = x.Index(i)
+
+	case *ssa.IndexAddr:
+		x := fr.get(instr.X)
+		if x.Kind() == reflect.Ptr {
+			if x.IsNil() {
+				throw(errNilDereference)
+			}
+			x = x.Elem()
+		}
+		i := ssaInt(fr.get(instr.Index))
+		if err := checkIndex(i, x.Len()); err != nil {
+			throw(err)
+		}
+		fr.env[instr] = x.Index(i).Addr()
+
+	case *ssa.Lookup:
+		fr.env[instr] = fr.lookup(instr)
+
+	case *ssa.MakeChan:
+		t := fr.in.typeOf(instr.Type())
+		size := ssaInt(fr.get(instr.Size))
+		fr.in.checkSize(size)
+		fr.env[instr] = reflect.MakeChan(t, size)
+
+	case *ssa.MakeMap:
+		t := fr.in.typeOf(instr.Type())
+		size := 0
+		if instr.Reserve != nil {
+			size = ssaInt(fr.get(instr.Reserve))
+		}
+		fr.in.checkSize(size)
+		fr.env[instr] = reflect.MakeMapWithSize(t, size)
+
+	case *ssa.MakeSlice:
+		length, capacity := ssaInt(fr.get(instr.Len)), ssaInt(fr.get(instr.Cap))
+		if length < 0 {
+			throw(errors.New("runtime error: makeslice: len out of range"))
+		}
+		if capacity < length {
+			throw(errors.New("runtime error: makeslice: cap out of range"))
+		}
+		fr.in.checkSize(capacity)
+		fr.env[instr] = reflect.MakeSlice(fr.in.typeOf(instr.Type()), length, capacity)
+
+	case *ssa.MakeClosure:
+		var bindings []reflect.Value
+		for _, b := range instr.Bindings {
+			bindings = append(bindings, fr.get(b))
+		}
+		fr.closures[instr] = bindings
+		fr.env[instr] = fr.in.makeFunc(instr.Fn.(*ssa.Function), bindings)
+
+	case *ssa.MapUpdate:
+		m := fr.get(instr.Map)
+		if m.IsNil() {
+			throw(errors.New("assignment to entry in nil map"))
+		}
+		m.SetMapIndex(ssaFit(fr.get(instr.Key), m.Type().Key()), ssaFit(fr.get(instr.Value), m.Type().Elem()))
+
+	case *ssa.Range:
+		x := fr.get(instr.X)
+		it := &ssaIter{x: x}
+		if x.Kind() == reflect.Map {
+			it.keys = x.MapKeys()
+		}
+		fr.env[instr] = reflect.ValueOf(it)
+
+	case *ssa.Next:
+		fr.env[instr] = reflect.ValueOf(fr.next(instr))
+
+	case *ssa.Select:
+		fr.env[instr] = reflect.ValueOf(fr.selectStates(instr))
+
+	case *ssa.Send:
+		ch := fr.get(instr.Chan)
+		if err := send(fr.in.scope.context(), fr.in.scope.limits(), ch, ssaFit(fr.get(instr.X), ch.Type().Elem())); err != nil {
+			throw(err)
+		}
+
+	case *ssa.Slice:
+		fr.env[instr] = fr.slice(instr)
+
+	case *ssa.Store:
+		ptr := fr.get(instr.Addr)
+		if ptr.IsNil() {
+			throw(errNilDereference)
+		}
+		ptr.Elem().Set(ssaFit(fr.get(instr.Val), ptr.Type().Elem()))
+
+	case *ssa.TypeAssert:
+		fr.env[instr] = fr.typeAssert(instr)
+
+	case *ssa.Go:
+		child := fr.in.scope.NewChild()
+		forked := *fr
+		forked.in = fr.in.fork(child)
+		call := forked.prepareCall(instr.Common())
+		source, ok := fr.in.goStmts[instr.Pos()]
+		if !ok {
+			source = "go " + instr.Call.String()
+		}
+		err := fr.in.scope.spawn(source, func(ctx context.Context) error {
+			child.ctx = ctx
+			call(nil)
+			return nil
+		})
+		if err != nil {
+			throw(err)
+		}
+
+	case *ssa.Defer:
+		call := fr.prepareCall(instr.Common())
+		fr.defers = append(fr.defers, func() { call(fr) })
+
+	case *ssa.RunDefers:
+		fr.runDefers()
+
+	case *ssa.Panic:
+		panic(ssaPanic{fr.get(instr.X).Interface()})
+
+	case *ssa.Jump:
+		fr.in.checkpoint()
+		fr.prev, fr.block = fr.block, fr.block.Succs[0]
+		return true
+
+	case *ssa.If:
+		fr.in.checkpoint()
+		succ := fr.block.Succs[1]
+		if fr.get(instr.Cond).Bool() {
+			succ = fr.block.Succs[0]
+		}
+		fr.prev, fr.block = fr.block, succ
+		return true
+
+	case *ssa.Return:
+		results := fr.fn.Signature.Results()
+		fr.results = make([]reflect.Value, len(instr.Results))
+		for i, r := range instr.Results {
+			fr.results[i] = ssaFit(fr.get(r), fr.in.typeOf(results.At(i).Type()))
+		}
+		fr.block = nil
+		return true
+
+	default:
+		throw(errors.Errorf("unsupported instruction %s", instr))
+	}
+	return false
+}
+
+// prepareCall evaluates the function and arguments of c and returns a
+// function that performs the call. caller is only passed for deferred calls.
+func (fr *ssaFrame) prepareCall(c *ssa.CallCommon) func(caller *ssaFrame) []reflect.Value {
+	args := make([]reflect.Value, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = fr.get(arg)
+	}
+	if c.IsInvoke() {
+		recv := fr.get(c.Value)
+		return func(*ssaFrame) []reflect.Value {
+			if recv.Kind() == reflect.Interface {
+				if recv.IsNil() {
+					throw(errNilDereference)
+				}
+				recv = recv.Elem()
+			}
+			m := recv.MethodByName(c.Method.Name())
+			if !m.IsValid() {
+				throw(errors.Errorf("method %s of %s is not available", c.Method.Name(), recv.Type()))
+			}
+			return fr.in.callValue(m, args)
+		}
+	}
+	switch fn := c.Value.(type) {
+	case *ssa.Builtin:
+		return func(*ssaFrame) []reflect.Value {
+			return fr.builtin(fn.Name(), args, c)
+		}
+	case *ssa.Function:
+		return func(caller *ssaFrame) []reflect.Value {
+			return fr.in.callFunction(fn, args, nil, caller)
+		}
+	case *ssa.MakeClosure:
+		if bindings, ok := fr.closures[fn]; ok {
+			return func(caller *ssaFrame) []reflect.Value {
+				return fr.in.callFunction(fn.Fn.(*ssa.Function), args, bindings, caller)
+			}
+		}
+	}
+	f := fr.get(c.Value)
+	return func(*ssaFrame) []reflect.Value {
+		return fr.in.callValue(f, args)
+	}
+}
+
+func (fr *ssaFrame) builtin(name string, args []reflect.Value, c *ssa.CallCommon) []reflect.Value {
+	resultType := func() reflect.Type {
+		return fr.in.typeOf(c.Signature().Results().At(0).Type())
+	}
+	switch name {
+	case "append":
+		if len(args) == 1 {
+			return args
+		}
+		x := args[1]
+		if x.Kind() == reflect.String {
+			x = x.Convert(reflect.TypeOf([]byte(nil)))
+		}
+		fr.in.checkSize(args[0].Len() + x.Len())
+		return []reflect.Value{reflect.AppendSlice(args[0], x)}
+
+	case "len", "cap":
+		x := args[0]
+		if x.Kind() == reflect.Ptr {
+			return []reflect.Value{reflect.ValueOf(x.Type().Elem().Len())}
+		}
+		if name == "cap" {
+			return []reflect.Value{reflect.ValueOf(x.Cap())}
+		}
+		return []reflect.Value{reflect.ValueOf(x.Len())}
+
+	case "close":
+		args[0].Close()
+		return nil
+
+	case "copy":
+		src := args[1]
+		if src.Kind() == reflect.String {
+			src = src.Convert(reflect.TypeOf([]byte(nil)))
+		}
+		return []reflect.Value{reflect.ValueOf(reflect.Copy(args[0], src))}
+
+	case "delete":
+		if m := args[0]; !m.IsNil() {
+			m.SetMapIndex(ssaFit(args[1], m.Type().Key()), reflect.Value{})
+		}
+		return nil
+
+	case "complex":
+		v := complex(args[0].Float(), args[1].Float())
+		return []reflect.Value{reflect.ValueOf(v).Convert(resultType())}
+
+	case "real":
+		return []reflect.Value{reflect.ValueOf(real(args[0].Complex())).Convert(resultType())}
+
+	case "imag":
+		return []reflect.Value{reflect.ValueOf(imag(args[0].Complex())).Convert(resultType())}
+
+	case "panic":
+		panic(ssaPanic{args[0].Interface()})
+
+	case "print", "println":
+		vals := make([]interface{}, len(args))
+		for i, arg := range args {
+			vals[i] = arg.Interface()
+		}
+		if name == "print" {
+			fmt.Fprint(os.Stderr, vals...)
+		} else {
+			fmt.Fprintln(os.Stderr, vals...)
+		}
+		return nil
+
+	case "recover":
+		v := reflect.New(emptyInterfaceType).Elem()
+		if c := fr.caller; c != nil && c.panicking {
+			c.panicking = false
+			r := c.panicValue
+			switch p := r.(type) {
+			case ssaPanic:
+				r = p.value
+			case runtimePanic:
+				r = p.err
+			}
+			if r != nil {
+				v.Set(reflect.ValueOf(r))
+			}
+		}
+		return []reflect.Value{v}
+
+	case "ssa:wrapnilchk":
+		if args[0].IsNil() {
+			throw(errors.Errorf("value method %s.%s called using nil *%s pointer", args[1], args[2], args[1]))
+		}
+		return args[:1]
+	}
+	throw(errors.Errorf("unsupported builtin %s", name))
+	return nil
+}
+
+func (fr *ssaFrame) unOp(instr *ssa.UnOp) reflect.Value {
+	x := fr.get(instr.X)
+	switch instr.Op {
+	case token.MUL:
+		if x.IsNil() {
+			throw(errNilDereference)
+		}
+		v := reflect.New(x.Type().Elem()).Elem()
+		v.Set(x.Elem())
+		return v
+	case token.ARROW:
+		v, ok, err := recv(fr.in.scope.context(), fr.in.scope.limits(), x)
+		if err != nil {
+			throw(err)
+		}
+		if instr.CommaOk {
+			return reflect.ValueOf(tuple{v, reflect.ValueOf(ok)})
+		}
+		return v
+	}
+	r := reflect.New(x.Type()).Elem()
+	switch instr.Op {
+	case token.NOT:
+		r.SetBool(!x.Bool())
+		return r
+	case token.SUB:
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			r.SetInt(-x.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			r.SetUint(-x.Uint())
+		case reflect.Float32, reflect.Float64:
+			r.SetFloat(-x.Float())
+		case reflect.Complex64, reflect.Complex128:
+			r.SetComplex(-x.Complex())
+		}
+		return r
+	case token.XOR:
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			r.SetInt(^x.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			r.SetUint(^x.Uint())
+		}
+		return r
+	}
+	throw(errors.Errorf("unsupported unary operator %s", instr.Op))
+	return r
+}
+
+func (fr *ssaFrame) lookup(instr *ssa.Lookup) reflect.Value {
+	x, key := fr.get(instr.X), fr.get(instr.Index)
+	if x.Kind() == reflect.String {
+		i := ssaInt(key)
+		if err := checkIndex(i, x.Len()); err != nil {
+			throw(err)
+		}
+		return x.Index(i)
+	}
+	v := x.MapIndex(ssaFit(key, x.Type().Key()))
+	ok := v.IsValid()
+	if !ok {
+		v = reflect.Zero(x.Type().Elem())
+	}
+	if instr.CommaOk {
+		return reflect.ValueOf(tuple{v, reflect.ValueOf(ok)})
+	}
+	return v
+}
+
+func (fr *ssaFrame) next(instr *ssa.Next) tuple {
+	it := fr.get(instr.Iter).Interface().(*ssaIter)
+	if instr.IsString {
+		s := it.x.String()
+		if it.i >= len(s) {
+			return tuple{reflect.ValueOf(false), reflect.ValueOf(0), reflect.ValueOf(rune(0))}
+		}
+		r, size := utf8.DecodeRuneInString(s[it.i:])
+		out := tuple{reflect.ValueOf(true), reflect.ValueOf(it.i), reflect.ValueOf(r)}
+		it.i += size
+		return out
+	}
+	for it.i < len(it.keys) {
+		k := it.keys[it.i]
+		it.i++
+		// Entries deleted during iteration are skipped.
+		if v := it.x.MapIndex(k); v.IsValid() {
+			return tuple{reflect.ValueOf(true), k, v}
+		}
+	}
+	t := it.x.Type()
+	return tuple{reflect.ValueOf(false), reflect.Zero(t.Key()), reflect.Zero(t.Elem())}
+}
+
+func (fr *ssaFrame) selectStates(instr *ssa.Select) tuple {
+	var cases []reflect.SelectCase
+	for _, st := range instr.States {
+		ch := fr.get(st.Chan)
+		if st.Dir == types.SendOnly {
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: ch,
+				Send: ssaFit(fr.get(st.Send), ch.Type().Elem()),
+			})
+		} else {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: ch})
+		}
+	}
+	if !instr.Blocking {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	}
+	chosen, recv, recvOK, err := selectCases(fr.in.scope.context(), fr.in.scope.limits(), cases)
+	if err != nil {
+		throw(err)
+	}
+	if chosen == len(instr.States) {
+		chosen = -1
+	}
+	out := tuple{reflect.ValueOf(chosen), reflect.ValueOf(recvOK)}
+	results := instr.Type().(*types.Tuple)
+	for i, st := range instr.States {
+		if st.Dir != types.RecvOnly {
+			continue
+		}
+		v := recv
+		if i != chosen || !v.IsValid() {
+			v = reflect.Zero(fr.in.typeOf(results.At(len(out)).Type()))
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func (fr *ssaFrame) slice(instr *ssa.Slice) reflect.Value {
+	x := fr.get(instr.X)
+	if x.Kind() == reflect.Ptr {
+		if x.IsNil() {
+			throw(errNilDereference)
+		}
+		x = x.Elem()
+	}
+	low, high, max := 0, x.Len(), x.Len()
+	if x.Kind() == reflect.Slice {
+		max = x.Cap()
+	}
+	if instr.Low != nil {
+		low = ssaInt(fr.get(instr.Low))
+	}
+	if instr.High != nil {
+		high = ssaInt(fr.get(instr.High))
+	}
+	if instr.Max != nil {
+		max = ssaInt(fr.get(instr.Max))
+	}
+	v, err := sliceValue(x, low, high, max, instr.Max != nil)
+	if err != nil {
+		throw(err)
+	}
+	return ssaFit(reflect.ValueOf(v), fr.in.typeOf(instr.Type()))
+}
+
+func (fr *ssaFrame) typeAssert(instr *ssa.TypeAssert) reflect.Value {
+	t := fr.in.typeOf(instr.AssertedType)
+	x := fr.get(instr.X)
+	ok := false
+	var v reflect.Value
+	if !x.IsNil() {
+		v = x.Elem()
+		ok = ssaAssertable(v.Type(), instr.AssertedType, t)
+	}
+	if instr.CommaOk {
+		if ok {
+			v = ssaFit(v, t)
+		} else {
+			v = reflect.Zero(t)
+		}
+		return reflect.ValueOf(tuple{v, reflect.ValueOf(ok)})
+	}
+	if !ok {
+		if !v.IsValid() {
+			throw(errors.Errorf("interface conversion: interface is nil, not %s", instr.AssertedType))
+		}
+		throw(errors.Errorf("interface conversion: %s is %s, not %s", instr.X.Type(), v.Type(), instr.AssertedType))
+	}
+	return ssaFit(v, t)
+}
+
+// ssaAssertable returns whether a dynamic value of type dyn satisfies the
+// type assertion to t. Interfaces represented by interface{} are checked by
+// their method names.
+func ssaAssertable(dyn reflect.Type, t types.Type, rType reflect.Type) bool {
+	if !types.IsInterface(t) {
+		return dyn == rType
+	}
+	if rType.NumMethod() > 0 {
+		return dyn.Implements(rType)
+	}
+	iface := t.Underlying().(*types.Interface)
+	for i := 0; i < iface.NumMethods(); i++ {
+		if _, ok := dyn.MethodByName(iface.Method(i).Name()); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ssaFit converts v to the runtime representation t, unwrapping and wrapping
+// interfaces as needed.
+func ssaFit(v reflect.Value, t reflect.Type) reflect.Value {
+	if !v.IsValid() {
+		return reflect.Zero(t)
+	}
+	if v.Type() == t {
+		return v
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+		v = v.Elem()
+	}
+	if v.Type().AssignableTo(t) {
+		if t.Kind() == reflect.Interface {
+			r := reflect.New(t).Elem()
+			r.Set(v)
+			return r
+		}
+		return v
+	}
+	if v.Type().ConvertibleTo(t) {
+		return v.Convert(t)
+	}
+	return v
+}
+
+// ssaField returns field i of the struct x. Unexported fields are accessed
+// through their address so the interpreter can read and write them.
+func ssaField(x reflect.Value, i int) reflect.Value {
+	f := x.Field(i)
+	if f.CanInterface() {
+		return f
+	}
+	if !x.CanAddr() {
+		c := reflect.New(x.Type()).Elem()
+		c.Set(x)
+		f = c.Field(i)
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+func ssaInt(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(v.Uint())
+	}
+	return int(v.Int())
+}
+
+func ssaShift(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	}
+	if v.Int() < 0 {
+		throw(errors.New("runtime error: negative shift amount"))
+	}
+	return uint64(v.Int())
+}
+
+func ssaEqual(x, y reflect.Value) bool {
+	switch x.Kind() {
+	case reflect.Func, reflect.Map, reflect.Slice:
+		// These can only be compared to nil.
+		return x.IsNil() && y.IsNil()
+	}
+	return x.Interface() == y.Interface()
+}
+
+func ssaBinOp(op token.Token, x, y reflect.Value) reflect.Value {
+	switch op {
+	case token.EQL:
+		return reflect.ValueOf(ssaEqual(x, y))
+	case token.NEQ:
+		return reflect.ValueOf(!ssaEqual(x, y))
+	}
+	r := reflect.New(x.Type()).Elem()
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		a := x.Int()
+		switch op {
+		case token.SHL:
+			r.SetInt(a << ssaShift(y))
+			return r
+		case token.SHR:
+			r.SetInt(a >> ssaShift(y))
+			return r
+		}
+		b := y.Int()
+		switch op {
+		case token.ADD:
+			r.SetInt(a + b)
+		case token.SUB:
+			r.SetInt(a - b)
+		case token.MUL:
+			r.SetInt(a * b)
+		case token.QUO, token.REM:
+			if b == 0 {
+				throw(errors.New("runtime error: integer divide by zero"))
+			}
+			if op == token.QUO {
+				r.SetInt(a / b)
+			} else {
+				r.SetInt(a % b)
+			}
+		case token.AND:
+			r.SetInt(a & b)
+		case token.OR:
+			r.SetInt(a | b)
+		case token.XOR:
+			r.SetInt(a ^ b)
+		case token.AND_NOT:
+			r.SetInt(a &^ b)
+		case token.LSS:
+			return reflect.ValueOf(a < b)
+		case token.LEQ:
+			return reflect.ValueOf(a <= b)
+		case token.GTR:
+			return reflect.ValueOf(a > b)
+		case token.GEQ:
+			return reflect.ValueOf(a >= b)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		a := x.Uint()
+		switch op {
+		case token.SHL:
+			r.SetUint(a << ssaShift(y))
+			return r
+		case token.SHR:
+			r.SetUint(a >> ssaShift(y))
+			return r
+		}
+		b := y.Uint()
+		switch op {
+		case token.ADD:
+			r.SetUint(a + b)
+		case token.SUB:
+			r.SetUint(a - b)
+		case token.MUL:
+			r.SetUint(a * b)
+		case token.QUO, token.REM:
+			if b == 0 {
+				throw(errors.New("runtime error: integer divide by zero"))
+			}
+			if op == token.QUO {
+				r.SetUint(a / b)
+			} else {
+				r.SetUint(a % b)
+			}
+		case token.AND:
+			r.SetUint(a & b)
+		case token.OR:
+			r.SetUint(a | b)
+		case token.XOR:
+			r.SetUint(a ^ b)
+		case token.AND_NOT:
+			r.SetUint(a &^ b)
+		case token.LSS:
+			return reflect.ValueOf(a < b)
+		case token.LEQ:
+			return reflect.ValueOf(a <= b)
+		case token.GTR:
+			return reflect.ValueOf(a > b)
+		case token.GEQ:
+			return reflect.ValueOf(a >= b)
+		}
+	case reflect.Float32, reflect.Float64:
+		a, b := x.Float(), y.Float()
+		switch op {
+		case token.ADD:
+			r.SetFloat(a + b)
+		case token.SUB:
+			r.SetFloat(a - b)
+		case token.MUL:
+			r.SetFloat(a * b)
+		case token.QUO:
+			r.SetFloat(a / b)
+		case token.LSS:
+			return reflect.ValueOf(a < b)
+		case token.LEQ:
+			return reflect.ValueOf(a <= b)
+		case token.GTR:
+			return reflect.ValueOf(a > b)
+		case token.GEQ:
+			return reflect.ValueOf(a >= b)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		a, b := x.Complex(), y.Complex()
+		switch op {
+		case token.ADD:
+			r.SetComplex(a + b)
+		case token.SUB:
+			r.SetComplex(a - b)
+		case token.MUL:
+			r.SetComplex(a * b)
+		case token.QUO:
+			r.SetComplex(a / b)
+		}
+	case reflect.String:
+		a, b := x.String(), y.String()
+		switch op {
+		case token.ADD:
+			r.SetString(a + b)
+		case token.LSS:
+			return reflect.ValueOf(a < b)
+		case token.LEQ:
+			return reflect.ValueOf(a <= b)
+		case token.GTR:
+			return reflect.ValueOf(a > b)
+		case token.GEQ:
+			return reflect.ValueOf(a >= b)
+		}
+	default:
+		throw(errors.Errorf("unsupported operands for %s: %s", op, x.Type()))
+	}
+	return r
+}
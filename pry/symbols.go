@@ -0,0 +1,156 @@
+package pry
+
+import (
+	"bytes"
+	"fmt"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// SymbolTable returns the source of a file in package pkgName that registers
+// the exported symbols of pkgs with RegisterPackage.
+func SymbolTable(pkgName string, pkgs []*types.Package) ([]byte, error) {
+	aliases := map[string]string{}
+	used := map[string]bool{"pry": true}
+	var paths []string
+	for _, pkg := range pkgs {
+		if pkg.Name() == "main" {
+			return nil, errors.Errorf("%s: can't extract package main", pkg.Path())
+		}
+		if _, ok := aliases[pkg.Path()]; ok {
+			continue
+		}
+		alias := pkg.Name()
+		for i := 1; used[alias]; i++ {
+			alias = pkg.Name() + strconv.Itoa(i)
+		}
+		used[alias] = true
+		aliases[pkg.Path()] = alias
+		paths = append(paths, pkg.Path())
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go-pry extract. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkgName)
+	buf.WriteString("\"github.com/d4l3k/go-pry/pry\"\n")
+	for _, p := range paths {
+		if alias := aliases[p]; alias != path.Base(p) {
+			fmt.Fprintf(&buf, "%s ", alias)
+		}
+		fmt.Fprintf(&buf, "%q\n", p)
+	}
+	buf.WriteString(")\n\nfunc init() {\n")
+	for _, pkg := range pkgs {
+		fmt.Fprintf(&buf, "pry.RegisterPackage(%q, %q, map[string]interface{}{\n", pkg.Path(), pkg.Name())
+		buf.WriteString(SymbolEntries(aliases[pkg.Path()]+".", "pry", Symbols(pkg, types.Object.Exported)))
+		buf.WriteString("})\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "format %s", buf.String())
+	}
+	return src, nil
+}
+
+// Symbol is an entry of a symbol table. Kind is "func", "var", "const" or
+// "type". Conv is the conversion constants are registered with, if any.
+type Symbol struct {
+	Name string
+	Kind string
+	Conv string `json:",omitempty"`
+}
+
+// Symbols returns the symbols of pkg include returns true for. Generic
+// functions and types are skipped since they can't be referenced without
+// instantiating them, and so are constants that don't fit in a uint64.
+func Symbols(pkg *types.Package, include func(types.Object) bool) []Symbol {
+	var syms []Symbol
+	for _, name := range pkg.Scope().Names() {
+		obj := pkg.Scope().Lookup(name)
+		if !include(obj) || isGeneric(obj) {
+			continue
+		}
+		sym := Symbol{Name: name}
+		switch obj := obj.(type) {
+		case *types.Func:
+			sym.Kind = "func"
+		case *types.Var:
+			sym.Kind = "var"
+		case *types.Const:
+			conv, ok := constConv(obj)
+			if !ok {
+				continue
+			}
+			sym.Kind = "const"
+			sym.Conv = conv
+		case *types.TypeName:
+			sym.Kind = "type"
+		default:
+			continue
+		}
+		syms = append(syms, sym)
+	}
+	return syms
+}
+
+// SymbolEntries returns the entries of a symbol table for syms, referenced
+// with the qualifier, with this package imported as pryName. Functions and
+// constants are registered by value, variables as pointers to them and types
+// as a reflect.Type.
+func SymbolEntries(qualifier, pryName string, syms []Symbol) string {
+	var buf bytes.Buffer
+	for _, sym := range syms {
+		ref := qualifier + sym.Name
+		val := ref
+		switch sym.Kind {
+		case "var":
+			val = "&" + ref
+		case "const":
+			if sym.Conv != "" {
+				val = sym.Conv + "(" + ref + ")"
+			}
+		case "type":
+			val = pryName + ".Type((*" + ref + ")(nil)).Elem()"
+		}
+		fmt.Fprintf(&buf, "%q: %s,\n", sym.Name, val)
+	}
+	return buf.String()
+}
+
+// constConv returns the conversion the constant obj has to be stored in an
+// interface{} with, which gives it its default type if it's untyped. Untyped
+// integers become ints, so those that overflow an int are converted to int64
+// or uint64 instead. It returns false if they don't fit either.
+func constConv(obj *types.Const) (string, bool) {
+	basic, ok := obj.Type().(*types.Basic)
+	if !ok || basic.Info()&types.IsUntyped == 0 || obj.Val().Kind() != constant.Int {
+		return "", true
+	}
+	if fitsInt(obj.Val()) {
+		return "", true
+	}
+	if _, exact := constant.Int64Val(obj.Val()); exact {
+		return "int64", true
+	}
+	if _, exact := constant.Uint64Val(obj.Val()); exact {
+		return "uint64", true
+	}
+	return "", false
+}
+
+// fitsInt reports whether the integer constant v fits in an int on 32 bit
+// platforms too, so the generated file builds everywhere.
+func fitsInt(v constant.Value) bool {
+	return constant.Compare(v, token.LEQ, constant.MakeInt64(1<<31-1)) &&
+		constant.Compare(v, token.GEQ, constant.MakeInt64(-1<<31))
+}
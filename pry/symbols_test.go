@@ -0,0 +1,74 @@
+package pry
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestSymbolTable(t *testing.T) {
+	t.Parallel()
+
+	src := `package shapes
+
+type Shape interface{ Area() float64 }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+func New(side float64) Square { return Square{side} }
+
+var Default = Square{1}
+
+const (
+	Sides       = 4
+	Huge        = 1 << 40
+	Max         = 1<<64 - 1
+	TooBig      = 1 << 70
+	Name string = "square"
+)
+
+var unexported int
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "shapes.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := (&types.Config{}).Check("example.com/go-shapes", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := SymbolTable("main", []*types.Package{pkg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `// Code generated by go-pry extract. DO NOT EDIT.
+
+package main
+
+import (
+	shapes "example.com/go-shapes"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("example.com/go-shapes", "shapes", map[string]interface{}{
+		"Default": &shapes.Default,
+		"Huge":    int64(shapes.Huge),
+		"Max":     uint64(shapes.Max),
+		"Name":    shapes.Name,
+		"New":     shapes.New,
+		"Shape":   pry.Type((*shapes.Shape)(nil)).Elem(),
+		"Sides":   shapes.Sides,
+		"Square":  pry.Type((*shapes.Square)(nil)).Elem(),
+	})
+}
+`
+	if string(out) != expected {
+		t.Errorf("Expected %s got %s.", expected, out)
+	}
+}
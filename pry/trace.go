@@ -0,0 +1,205 @@
+package pry
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"strings"
+	"sync"
+)
+
+// source is the input an evaluated node was parsed from. Positions of nodes
+// are relative to the input wrapped by ParseString, shifted by shifted bytes.
+type source struct {
+	src     string
+	shifted int
+}
+
+// offset returns the byte offset of pos in the input, or -1 if pos is outside
+// of it.
+func (s *source) offset(pos token.Pos) int {
+	off := int(pos) - 1 - s.shifted
+	if off < 0 || off > len(s.src) {
+		return -1
+	}
+	return off
+}
+
+// position returns the line and column of pos in the input.
+func (s *source) position(pos token.Pos) string {
+	off := s.offset(pos)
+	if off < 0 {
+		return "-"
+	}
+	line := 1 + strings.Count(s.src[:off], "\n")
+	col := off - strings.LastIndex(s.src[:off], "\n")
+	return fmt.Sprintf("%d:%d", line, col)
+}
+
+// text returns the first line of the source of node.
+func (s *source) text(node ast.Node) string {
+	start, end := s.offset(node.Pos()), s.offset(node.End())
+	if start < 0 || end < start {
+		return ""
+	}
+	text := s.src[start:end]
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// currentSource returns the input the code running in scope was parsed from.
+func (scope *Scope) currentSource() *source {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		src := s.source
+		s.Unlock()
+		if src != nil {
+			return src
+		}
+	}
+	return nil
+}
+
+// withSource makes src the input of code running in scope until the returned
+// function is called.
+func (scope *Scope) withSource(src *source) func() {
+	scope.Lock()
+	prev := scope.source
+	scope.source = src
+	scope.Unlock()
+	return func() {
+		scope.Lock()
+		scope.source = prev
+		scope.Unlock()
+	}
+}
+
+// tracer writes the statements executed by the interpreter.
+type tracer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetTrace makes evaluations in scope and its children log each executed
+// statement to w with its position in the input, the variables it assigned
+// and the branch it took. A nil writer turns tracing off. Traced input is
+// always walked rather than compiled.
+func (scope *Scope) SetTrace(w io.Writer) {
+	scope.Lock()
+	defer scope.Unlock()
+	if w == nil {
+		scope.trace = nil
+		return
+	}
+	scope.trace = &tracer{w: w}
+}
+
+// tracing returns the tracer of scope, or nil if tracing is off.
+func (scope *Scope) tracing() *tracer {
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		t := s.trace
+		s.Unlock()
+		if t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+func (t *tracer) printf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, format, args...)
+}
+
+// traced reports whether node is a statement logged by the tracer.
+func traced(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+		return false
+	case ast.Stmt:
+		return true
+	}
+	return false
+}
+
+// traceStmt logs stmt before it's executed.
+func (scope *Scope) traceStmt(t *tracer, stmt ast.Stmt) {
+	src := scope.currentSource()
+	if src == nil {
+		return
+	}
+	t.printf("%-6s %s\n", src.position(stmt.Pos()), src.text(stmt))
+}
+
+// traceAssigned logs the variables assigned by stmt after it's executed.
+func (scope *Scope) traceAssigned(t *tracer, stmt ast.Stmt) {
+	var names []*ast.Ident
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range s.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				names = append(names, ident)
+			}
+		}
+	case *ast.IncDecStmt:
+		if ident, ok := s.X.(*ast.Ident); ok {
+			names = append(names, ident)
+		}
+	case *ast.DeclStmt:
+		if decl, ok := s.Decl.(*ast.GenDecl); ok && decl.Tok == token.VAR {
+			for _, spec := range decl.Specs {
+				names = append(names, spec.(*ast.ValueSpec).Names...)
+			}
+		}
+	}
+	for _, name := range names {
+		if name.Name == "_" {
+			continue
+		}
+		v, ok := scope.Get(name.Name)
+		if !ok {
+			continue
+		}
+		val := fmt.Sprintf("%#v", v)
+		if fn, isFunc := v.(*Func); isFunc {
+			val = fn.String()
+		}
+		t.printf("%-6s   %s = %s\n", "", name.Name, val)
+	}
+}
+
+// traceBranch logs the branch taken by a conditional statement.
+func (scope *Scope) traceBranch(branch string) {
+	if t := scope.tracing(); t != nil {
+		t.printf("%-6s   -> %s\n", "", branch)
+	}
+}
+
+// traceClause logs the case of a switch or select statement that was taken.
+func (scope *Scope) traceClause(t *tracer, clause ast.Node) {
+	src := scope.currentSource()
+	if src == nil {
+		return
+	}
+	text := "default:"
+	switch c := clause.(type) {
+	case *ast.CaseClause:
+		if c.List != nil {
+			var exprs []string
+			for _, expr := range c.List {
+				exprs = append(exprs, src.text(expr))
+			}
+			text = "case " + strings.Join(exprs, ", ") + ":"
+		}
+	case *ast.CommClause:
+		if c.Comm != nil {
+			text = "case " + src.text(c.Comm) + ":"
+		}
+	}
+	t.printf("%-6s   -> %s\n", src.position(clause.Pos()), text)
+}
@@ -0,0 +1,401 @@
+package pry
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	errorType          = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// basicTypes maps go/types basic kinds to their reflect equivalent. Untyped
+// kinds map to the default type of the constant.
+var basicTypes = map[types.BasicKind]reflect.Type{
+	types.Bool:          reflect.TypeOf(false),
+	types.Int:           reflect.TypeOf(int(0)),
+	types.Int8:          reflect.TypeOf(int8(0)),
+	types.Int16:         reflect.TypeOf(int16(0)),
+	types.Int32:         reflect.TypeOf(int32(0)),
+	types.Int64:         reflect.TypeOf(int64(0)),
+	types.Uint:          reflect.TypeOf(uint(0)),
+	types.Uint8:         reflect.TypeOf(uint8(0)),
+	types.Uint16:        reflect.TypeOf(uint16(0)),
+	types.Uint32:        reflect.TypeOf(uint32(0)),
+	types.Uint64:        reflect.TypeOf(uint64(0)),
+	types.Uintptr:       reflect.TypeOf(uintptr(0)),
+	types.Float32:       reflect.TypeOf(float32(0)),
+	types.Float64:       reflect.TypeOf(float64(0)),
+	types.Complex64:     reflect.TypeOf(complex64(0)),
+	types.Complex128:    reflect.TypeOf(complex128(0)),
+	types.String:        reflect.TypeOf(""),
+	types.UnsafePointer: reflect.TypeOf(uintptr(0)),
+
+	types.UntypedBool:    reflect.TypeOf(false),
+	types.UntypedInt:     reflect.TypeOf(int(0)),
+	types.UntypedRune:    reflect.TypeOf(rune(0)),
+	types.UntypedFloat:   reflect.TypeOf(float64(0)),
+	types.UntypedComplex: reflect.TypeOf(complex128(0)),
+	types.UntypedString:  reflect.TypeOf(""),
+}
+
+// newTypeInfo returns a types.Info that records everything the interpreter
+// consumes.
+func newTypeInfo() *types.Info {
+	return &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+}
+
+// typeInfo returns the type information of the last successful type check.
+func (scope *Scope) typeInfo() *types.Info {
+	for ; scope != nil; scope = scope.Parent {
+		scope.Lock()
+		info := scope.info
+		scope.Unlock()
+		if info != nil {
+			return info
+		}
+	}
+	return nil
+}
+
+// staticType returns the type and value go/types recorded for expr.
+func (scope *Scope) staticType(expr ast.Expr) (types.TypeAndValue, bool) {
+	info := scope.typeInfo()
+	if info == nil {
+		return types.TypeAndValue{}, false
+	}
+	tv, ok := info.Types[expr]
+	return tv, ok && tv.Type != nil
+}
+
+// interpretTyped evaluates expr using the static type go/types recorded for
+// it. Constants are materialized directly with their final type and other
+// values are converted to it.
+func (scope *Scope) interpretTyped(expr ast.Expr) (interface{}, bool, error) {
+	tv, ok := scope.staticType(expr)
+	if !ok {
+		return nil, false, nil
+	}
+	if tv.IsNil() {
+		return nil, true, nil
+	}
+	rType, ok := scope.ReflectType(tv.Type)
+	if !ok {
+		return nil, false, nil
+	}
+	if tv.IsType() {
+		return rType, true, nil
+	}
+	if tv.Value != nil {
+		v, err := constantToValue(tv.Value, rType)
+		return v, err == nil, err
+	}
+	v, err := scope.interpret(expr)
+	if err != nil || v == nil {
+		return v, true, err
+	}
+	return convertStatic(v, rType), true, nil
+}
+
+// convertStatic converts v to the static type rType. Interface types keep the
+// dynamic value.
+func convertStatic(v interface{}, rType reflect.Type) interface{} {
+	val := reflect.ValueOf(v)
+	if rType.Kind() == reflect.Interface || val.Type() == rType || !val.Type().ConvertibleTo(rType) {
+		return v
+	}
+	return val.Convert(rType).Interface()
+}
+
+// constantToValue converts a go/types constant to a value of type rType.
+func constantToValue(c constant.Value, rType reflect.Type) (interface{}, error) {
+	var v reflect.Value
+	switch rType.Kind() {
+	case reflect.Bool:
+		v = reflect.ValueOf(constant.BoolVal(c))
+	case reflect.String:
+		v = reflect.ValueOf(constant.StringVal(c))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, exact := constant.Int64Val(constant.ToInt(c))
+		if !exact {
+			return nil, errors.Errorf("constant %s overflows %s", c, rType)
+		}
+		v = reflect.ValueOf(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, exact := constant.Uint64Val(constant.ToInt(c))
+		if !exact {
+			return nil, errors.Errorf("constant %s overflows %s", c, rType)
+		}
+		v = reflect.ValueOf(i)
+	case reflect.Float32, reflect.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(c))
+		v = reflect.ValueOf(f)
+	case reflect.Complex64, reflect.Complex128:
+		c = constant.ToComplex(c)
+		re, _ := constant.Float64Val(constant.Real(c))
+		im, _ := constant.Float64Val(constant.Imag(c))
+		v = reflect.ValueOf(complex(re, im))
+	default:
+		return nil, errors.Errorf("can't represent constant %s as %s", c, rType)
+	}
+	return v.Convert(rType).Interface(), nil
+}
+
+// ReflectType returns the reflect.Type corresponding to a go/types type.
+// Named types are resolved through the values in scope, since they can't be
+// constructed with reflection. Interfaces that can't be resolved are
+// represented by interface{}, which holds the same dynamic values.
+func (scope *Scope) ReflectType(t types.Type) (reflect.Type, bool) {
+	switch t := t.(type) {
+	case *types.Basic:
+		rType, ok := basicTypes[t.Kind()]
+		return rType, ok
+
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() == nil {
+			if obj.Name() == "error" {
+				return errorType, true
+			}
+			return nil, false
+		}
+		var v interface{}
+		var ok bool
+		if info := scope.typeInfo(); info != nil && scope.isLocalPackage(obj.Pkg()) {
+			v, ok = scope.Get(obj.Name())
+		} else if pkg, isPkg := scope.lookupPackage(obj.Pkg()); isPkg {
+			v, ok = pkg.Get(obj.Name())
+		}
+		if rType, isType := v.(reflect.Type); ok && isType {
+			return rType, true
+		}
+		if types.IsInterface(t) {
+			return emptyInterfaceType, true
+		}
+		return nil, false
+
+	case *types.Pointer:
+		elem, ok := scope.ReflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.PtrTo(elem), true
+
+	case *types.Slice:
+		elem, ok := scope.ReflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.SliceOf(elem), true
+
+	case *types.Array:
+		elem, ok := scope.ReflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.ArrayOf(int(t.Len()), elem), true
+
+	case *types.Map:
+		key, ok := scope.ReflectType(t.Key())
+		if !ok {
+			return nil, false
+		}
+		elem, ok := scope.ReflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.MapOf(key, elem), true
+
+	case *types.Chan:
+		elem, ok := scope.ReflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		dir := reflect.BothDir
+		switch t.Dir() {
+		case types.SendOnly:
+			dir = reflect.SendDir
+		case types.RecvOnly:
+			dir = reflect.RecvDir
+		}
+		return reflect.ChanOf(dir, elem), true
+
+	case *types.Interface:
+		return emptyInterfaceType, true
+
+	case *types.Signature:
+		in, ok := scope.reflectTuple(t.Params())
+		if !ok {
+			return nil, false
+		}
+		out, ok := scope.reflectTuple(t.Results())
+		if !ok {
+			return nil, false
+		}
+		return reflect.FuncOf(in, out, t.Variadic()), true
+
+	case *types.Struct:
+		var fields []reflect.StructField
+		for i := 0; i < t.NumFields(); i++ {
+			f := t.Field(i)
+			if !f.Exported() {
+				return nil, false
+			}
+			typ, ok := scope.ReflectType(f.Type())
+			if !ok {
+				return nil, false
+			}
+			fields = append(fields, reflect.StructField{
+				Name:      f.Name(),
+				Type:      typ,
+				Tag:       reflect.StructTag(t.Tag(i)),
+				Anonymous: f.Anonymous(),
+			})
+		}
+		return reflect.StructOf(fields), true
+	}
+	return nil, false
+}
+
+func (scope *Scope) reflectTuple(t *types.Tuple) ([]reflect.Type, bool) {
+	var out []reflect.Type
+	for i := 0; i < t.Len(); i++ {
+		typ, ok := scope.ReflectType(t.At(i).Type())
+		if !ok {
+			return nil, false
+		}
+		out = append(out, typ)
+	}
+	return out, true
+}
+
+// isLocalPackage returns whether pkg is the package the scope was type checked
+// against.
+func (scope *Scope) isLocalPackage(pkg *types.Package) bool {
+	for s := scope; s != nil; s = s.Parent {
+		if s.pkg != nil {
+			return s.pkg == pkg
+		}
+	}
+	return false
+}
+
+// lookupPackage finds the Package in scope that was imported as pkg.
+// Registered packages are matched by import path, since packages can share a
+// name, and packages built by hand without one by name.
+func (scope *Scope) lookupPackage(pkg *types.Package) (Package, bool) {
+	if v, ok := scope.Get(pkg.Name()); ok {
+		if p, ok := v.(Package); ok && isPackage(p, pkg) {
+			return p, true
+		}
+	}
+	for s := scope; s != nil; s = s.Parent {
+		s.Lock()
+		for _, v := range s.Vals {
+			if p, ok := v.(Package); ok && isPackage(p, pkg) {
+				s.Unlock()
+				return p, true
+			}
+		}
+		s.Unlock()
+	}
+	return Package{}, false
+}
+
+// isPackage reports whether p is the package pkg.
+func isPackage(p Package, pkg *types.Package) bool {
+	if p.Path != "" {
+		return p.Path == pkg.Path()
+	}
+	return p.Name == pkg.Name()
+}
+
+// objectType returns the reflect type of the variable ident declares or
+// refers to, if it is known statically.
+func (scope *Scope) objectType(ident *ast.Ident) (reflect.Type, bool) {
+	info := scope.typeInfo()
+	if info == nil {
+		return nil, false
+	}
+	obj := info.Defs[ident]
+	if obj == nil {
+		obj = info.Uses[ident]
+	}
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return nil, false
+	}
+	return scope.ReflectType(v.Type())
+}
+
+// Define declares name in the current scope, shadowing any variable of the
+// same name in a parent scope. If the static type of ident is known, the
+// variable is stored with that type so interface typed variables keep their
+// type across assignments.
+func (scope *Scope) Define(ident *ast.Ident, val interface{}) {
+	var ptr reflect.Value
+	if rType, ok := scope.objectType(ident); ok {
+		ptr = reflect.New(rType)
+		if val != nil {
+			ptr.Elem().Set(convertAssignable(reflect.ValueOf(val), rType))
+		}
+	} else if val != nil {
+		v := reflect.ValueOf(val)
+		ptr = reflect.New(v.Type())
+		ptr.Elem().Set(v)
+	}
+
+	scope.Lock()
+	defer scope.Unlock()
+	if ptr.IsValid() {
+		scope.bind(ident.Name, ptr.Interface())
+	} else {
+		scope.bind(ident.Name, nil)
+	}
+}
+
+// convertAssignable converts v to rType if it isn't directly assignable.
+// Conversions that change the meaning of the value, like int to string, are
+// never applied.
+func convertAssignable(v reflect.Value, rType reflect.Type) reflect.Value {
+	if v.Type().AssignableTo(rType) || !v.Type().ConvertibleTo(rType) {
+		return v
+	}
+	if (rType.Kind() == reflect.String) != (v.Kind() == reflect.String) {
+		return v
+	}
+	return v.Convert(rType)
+}
+
+// methodReceiver returns the value a method selected by e should be called
+// on. Methods with pointer receivers on addressable values are called on the
+// address of the value, as the Go method set rules require.
+func (scope *Scope) methodReceiver(e *ast.SelectorExpr, x reflect.Value) reflect.Value {
+	info := scope.typeInfo()
+	if info == nil || x.Kind() == reflect.Ptr {
+		return x
+	}
+	sel, ok := info.Selections[e]
+	if !ok || sel.Kind() != types.MethodVal {
+		return x
+	}
+	sig := sel.Obj().Type().(*types.Signature)
+	if _, isPtr := sig.Recv().Type().(*types.Pointer); !isPtr {
+		return x
+	}
+	addr, err := scope.getValue(e.X)
+	if err != nil || !addr.CanAddr() {
+		return x
+	}
+	return addr.Addr()
+}
@@ -0,0 +1,42 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"archive/tar"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("archive/tar", "tar", map[string]interface{}{
+		"ErrFieldTooLong":    &tar.ErrFieldTooLong,
+		"ErrHeader":          &tar.ErrHeader,
+		"ErrWriteAfterClose": &tar.ErrWriteAfterClose,
+		"ErrWriteTooLong":    &tar.ErrWriteTooLong,
+		"FileInfoHeader":     tar.FileInfoHeader,
+		"Format":             pry.Type((*tar.Format)(nil)).Elem(),
+		"FormatGNU":          tar.FormatGNU,
+		"FormatPAX":          tar.FormatPAX,
+		"FormatUSTAR":        tar.FormatUSTAR,
+		"FormatUnknown":      tar.FormatUnknown,
+		"Header":             pry.Type((*tar.Header)(nil)).Elem(),
+		"NewReader":          tar.NewReader,
+		"NewWriter":          tar.NewWriter,
+		"Reader":             pry.Type((*tar.Reader)(nil)).Elem(),
+		"TypeBlock":          tar.TypeBlock,
+		"TypeChar":           tar.TypeChar,
+		"TypeCont":           tar.TypeCont,
+		"TypeDir":            tar.TypeDir,
+		"TypeFifo":           tar.TypeFifo,
+		"TypeGNULongLink":    tar.TypeGNULongLink,
+		"TypeGNULongName":    tar.TypeGNULongName,
+		"TypeGNUSparse":      tar.TypeGNUSparse,
+		"TypeLink":           tar.TypeLink,
+		"TypeReg":            tar.TypeReg,
+		"TypeRegA":           tar.TypeRegA,
+		"TypeSymlink":        tar.TypeSymlink,
+		"TypeXGlobalHeader":  tar.TypeXGlobalHeader,
+		"TypeXHeader":        tar.TypeXHeader,
+		"Writer":             pry.Type((*tar.Writer)(nil)).Elem(),
+	})
+}
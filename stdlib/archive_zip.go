@@ -0,0 +1,31 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"archive/zip"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("archive/zip", "zip", map[string]interface{}{
+		"Compressor":           pry.Type((*zip.Compressor)(nil)).Elem(),
+		"Decompressor":         pry.Type((*zip.Decompressor)(nil)).Elem(),
+		"Deflate":              zip.Deflate,
+		"ErrAlgorithm":         &zip.ErrAlgorithm,
+		"ErrChecksum":          &zip.ErrChecksum,
+		"ErrFormat":            &zip.ErrFormat,
+		"File":                 pry.Type((*zip.File)(nil)).Elem(),
+		"FileHeader":           pry.Type((*zip.FileHeader)(nil)).Elem(),
+		"FileInfoHeader":       zip.FileInfoHeader,
+		"NewReader":            zip.NewReader,
+		"NewWriter":            zip.NewWriter,
+		"OpenReader":           zip.OpenReader,
+		"ReadCloser":           pry.Type((*zip.ReadCloser)(nil)).Elem(),
+		"Reader":               pry.Type((*zip.Reader)(nil)).Elem(),
+		"RegisterCompressor":   zip.RegisterCompressor,
+		"RegisterDecompressor": zip.RegisterDecompressor,
+		"Store":                zip.Store,
+		"Writer":               pry.Type((*zip.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,38 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"bufio"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("bufio", "bufio", map[string]interface{}{
+		"ErrAdvanceTooFar":     &bufio.ErrAdvanceTooFar,
+		"ErrBadReadCount":      &bufio.ErrBadReadCount,
+		"ErrBufferFull":        &bufio.ErrBufferFull,
+		"ErrFinalToken":        &bufio.ErrFinalToken,
+		"ErrInvalidUnreadByte": &bufio.ErrInvalidUnreadByte,
+		"ErrInvalidUnreadRune": &bufio.ErrInvalidUnreadRune,
+		"ErrNegativeAdvance":   &bufio.ErrNegativeAdvance,
+		"ErrNegativeCount":     &bufio.ErrNegativeCount,
+		"ErrTooLong":           &bufio.ErrTooLong,
+		"MaxScanTokenSize":     bufio.MaxScanTokenSize,
+		"NewReadWriter":        bufio.NewReadWriter,
+		"NewReader":            bufio.NewReader,
+		"NewReaderSize":        bufio.NewReaderSize,
+		"NewScanner":           bufio.NewScanner,
+		"NewWriter":            bufio.NewWriter,
+		"NewWriterSize":        bufio.NewWriterSize,
+		"ReadWriter":           pry.Type((*bufio.ReadWriter)(nil)).Elem(),
+		"Reader":               pry.Type((*bufio.Reader)(nil)).Elem(),
+		"ScanBytes":            bufio.ScanBytes,
+		"ScanLines":            bufio.ScanLines,
+		"ScanRunes":            bufio.ScanRunes,
+		"ScanWords":            bufio.ScanWords,
+		"Scanner":              pry.Type((*bufio.Scanner)(nil)).Elem(),
+		"SplitFunc":            pry.Type((*bufio.SplitFunc)(nil)).Elem(),
+		"Writer":               pry.Type((*bufio.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,67 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"bytes"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("bytes", "bytes", map[string]interface{}{
+		"Buffer":          pry.Type((*bytes.Buffer)(nil)).Elem(),
+		"Compare":         bytes.Compare,
+		"Contains":        bytes.Contains,
+		"ContainsAny":     bytes.ContainsAny,
+		"ContainsRune":    bytes.ContainsRune,
+		"Count":           bytes.Count,
+		"Equal":           bytes.Equal,
+		"EqualFold":       bytes.EqualFold,
+		"ErrTooLarge":     &bytes.ErrTooLarge,
+		"Fields":          bytes.Fields,
+		"FieldsFunc":      bytes.FieldsFunc,
+		"HasPrefix":       bytes.HasPrefix,
+		"HasSuffix":       bytes.HasSuffix,
+		"Index":           bytes.Index,
+		"IndexAny":        bytes.IndexAny,
+		"IndexByte":       bytes.IndexByte,
+		"IndexFunc":       bytes.IndexFunc,
+		"IndexRune":       bytes.IndexRune,
+		"Join":            bytes.Join,
+		"LastIndex":       bytes.LastIndex,
+		"LastIndexAny":    bytes.LastIndexAny,
+		"LastIndexByte":   bytes.LastIndexByte,
+		"LastIndexFunc":   bytes.LastIndexFunc,
+		"Map":             bytes.Map,
+		"MinRead":         bytes.MinRead,
+		"NewBuffer":       bytes.NewBuffer,
+		"NewBufferString": bytes.NewBufferString,
+		"NewReader":       bytes.NewReader,
+		"Reader":          pry.Type((*bytes.Reader)(nil)).Elem(),
+		"Repeat":          bytes.Repeat,
+		"Replace":         bytes.Replace,
+		"ReplaceAll":      bytes.ReplaceAll,
+		"Runes":           bytes.Runes,
+		"Split":           bytes.Split,
+		"SplitAfter":      bytes.SplitAfter,
+		"SplitAfterN":     bytes.SplitAfterN,
+		"SplitN":          bytes.SplitN,
+		"Title":           bytes.Title,
+		"ToLower":         bytes.ToLower,
+		"ToLowerSpecial":  bytes.ToLowerSpecial,
+		"ToTitle":         bytes.ToTitle,
+		"ToTitleSpecial":  bytes.ToTitleSpecial,
+		"ToUpper":         bytes.ToUpper,
+		"ToUpperSpecial":  bytes.ToUpperSpecial,
+		"ToValidUTF8":     bytes.ToValidUTF8,
+		"Trim":            bytes.Trim,
+		"TrimFunc":        bytes.TrimFunc,
+		"TrimLeft":        bytes.TrimLeft,
+		"TrimLeftFunc":    bytes.TrimLeftFunc,
+		"TrimPrefix":      bytes.TrimPrefix,
+		"TrimRight":       bytes.TrimRight,
+		"TrimRightFunc":   bytes.TrimRightFunc,
+		"TrimSpace":       bytes.TrimSpace,
+		"TrimSuffix":      bytes.TrimSuffix,
+	})
+}
@@ -0,0 +1,15 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"compress/bzip2"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("compress/bzip2", "bzip2", map[string]interface{}{
+		"NewReader":       bzip2.NewReader,
+		"StructuralError": pry.Type((*bzip2.StructuralError)(nil)).Elem(),
+	})
+}
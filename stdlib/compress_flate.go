@@ -0,0 +1,29 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"compress/flate"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("compress/flate", "flate", map[string]interface{}{
+		"BestCompression":    flate.BestCompression,
+		"BestSpeed":          flate.BestSpeed,
+		"CorruptInputError":  pry.Type((*flate.CorruptInputError)(nil)).Elem(),
+		"DefaultCompression": flate.DefaultCompression,
+		"HuffmanOnly":        flate.HuffmanOnly,
+		"InternalError":      pry.Type((*flate.InternalError)(nil)).Elem(),
+		"NewReader":          flate.NewReader,
+		"NewReaderDict":      flate.NewReaderDict,
+		"NewWriter":          flate.NewWriter,
+		"NewWriterDict":      flate.NewWriterDict,
+		"NoCompression":      flate.NoCompression,
+		"ReadError":          pry.Type((*flate.ReadError)(nil)).Elem(),
+		"Reader":             pry.Type((*flate.Reader)(nil)).Elem(),
+		"Resetter":           pry.Type((*flate.Resetter)(nil)).Elem(),
+		"WriteError":         pry.Type((*flate.WriteError)(nil)).Elem(),
+		"Writer":             pry.Type((*flate.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,26 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"compress/gzip"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("compress/gzip", "gzip", map[string]interface{}{
+		"BestCompression":    gzip.BestCompression,
+		"BestSpeed":          gzip.BestSpeed,
+		"DefaultCompression": gzip.DefaultCompression,
+		"ErrChecksum":        &gzip.ErrChecksum,
+		"ErrHeader":          &gzip.ErrHeader,
+		"Header":             pry.Type((*gzip.Header)(nil)).Elem(),
+		"HuffmanOnly":        gzip.HuffmanOnly,
+		"NewReader":          gzip.NewReader,
+		"NewWriter":          gzip.NewWriter,
+		"NewWriterLevel":     gzip.NewWriterLevel,
+		"NoCompression":      gzip.NoCompression,
+		"Reader":             pry.Type((*gzip.Reader)(nil)).Elem(),
+		"Writer":             pry.Type((*gzip.Writer)(nil)).Elem(),
+	})
+}
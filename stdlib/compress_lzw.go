@@ -0,0 +1,18 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"compress/lzw"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("compress/lzw", "lzw", map[string]interface{}{
+		"LSB":       lzw.LSB,
+		"MSB":       lzw.MSB,
+		"NewReader": lzw.NewReader,
+		"NewWriter": lzw.NewWriter,
+		"Order":     pry.Type((*lzw.Order)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,28 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"compress/zlib"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("compress/zlib", "zlib", map[string]interface{}{
+		"BestCompression":    zlib.BestCompression,
+		"BestSpeed":          zlib.BestSpeed,
+		"DefaultCompression": zlib.DefaultCompression,
+		"ErrChecksum":        &zlib.ErrChecksum,
+		"ErrDictionary":      &zlib.ErrDictionary,
+		"ErrHeader":          &zlib.ErrHeader,
+		"HuffmanOnly":        zlib.HuffmanOnly,
+		"NewReader":          zlib.NewReader,
+		"NewReaderDict":      zlib.NewReaderDict,
+		"NewWriter":          zlib.NewWriter,
+		"NewWriterLevel":     zlib.NewWriterLevel,
+		"NewWriterLevelDict": zlib.NewWriterLevelDict,
+		"NoCompression":      zlib.NoCompression,
+		"Resetter":           pry.Type((*zlib.Resetter)(nil)).Elem(),
+		"Writer":             pry.Type((*zlib.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,19 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"container/heap"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("container/heap", "heap", map[string]interface{}{
+		"Fix":       heap.Fix,
+		"Init":      heap.Init,
+		"Interface": pry.Type((*heap.Interface)(nil)).Elem(),
+		"Pop":       heap.Pop,
+		"Push":      heap.Push,
+		"Remove":    heap.Remove,
+	})
+}
@@ -0,0 +1,16 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"container/list"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("container/list", "list", map[string]interface{}{
+		"Element": pry.Type((*list.Element)(nil)).Elem(),
+		"List":    pry.Type((*list.List)(nil)).Elem(),
+		"New":     list.New,
+	})
+}
@@ -0,0 +1,23 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"context"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("context", "context", map[string]interface{}{
+		"Background":       context.Background,
+		"CancelFunc":       pry.Type((*context.CancelFunc)(nil)).Elem(),
+		"Canceled":         &context.Canceled,
+		"Context":          pry.Type((*context.Context)(nil)).Elem(),
+		"DeadlineExceeded": &context.DeadlineExceeded,
+		"TODO":             context.TODO,
+		"WithCancel":       context.WithCancel,
+		"WithDeadline":     context.WithDeadline,
+		"WithTimeout":      context.WithTimeout,
+		"WithValue":        context.WithValue,
+	})
+}
@@ -0,0 +1,40 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto", "crypto", map[string]interface{}{
+		"BLAKE2b_256":   crypto.BLAKE2b_256,
+		"BLAKE2b_384":   crypto.BLAKE2b_384,
+		"BLAKE2b_512":   crypto.BLAKE2b_512,
+		"BLAKE2s_256":   crypto.BLAKE2s_256,
+		"Decrypter":     pry.Type((*crypto.Decrypter)(nil)).Elem(),
+		"DecrypterOpts": pry.Type((*crypto.DecrypterOpts)(nil)).Elem(),
+		"Hash":          pry.Type((*crypto.Hash)(nil)).Elem(),
+		"MD4":           crypto.MD4,
+		"MD5":           crypto.MD5,
+		"MD5SHA1":       crypto.MD5SHA1,
+		"PrivateKey":    pry.Type((*crypto.PrivateKey)(nil)).Elem(),
+		"PublicKey":     pry.Type((*crypto.PublicKey)(nil)).Elem(),
+		"RIPEMD160":     crypto.RIPEMD160,
+		"RegisterHash":  crypto.RegisterHash,
+		"SHA1":          crypto.SHA1,
+		"SHA224":        crypto.SHA224,
+		"SHA256":        crypto.SHA256,
+		"SHA384":        crypto.SHA384,
+		"SHA3_224":      crypto.SHA3_224,
+		"SHA3_256":      crypto.SHA3_256,
+		"SHA3_384":      crypto.SHA3_384,
+		"SHA3_512":      crypto.SHA3_512,
+		"SHA512":        crypto.SHA512,
+		"SHA512_224":    crypto.SHA512_224,
+		"SHA512_256":    crypto.SHA512_256,
+		"Signer":        pry.Type((*crypto.Signer)(nil)).Elem(),
+		"SignerOpts":    pry.Type((*crypto.SignerOpts)(nil)).Elem(),
+	})
+}
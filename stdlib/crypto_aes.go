@@ -0,0 +1,16 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/aes"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/aes", "aes", map[string]interface{}{
+		"BlockSize":    aes.BlockSize,
+		"KeySizeError": pry.Type((*aes.KeySizeError)(nil)).Elem(),
+		"NewCipher":    aes.NewCipher,
+	})
+}
@@ -0,0 +1,28 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/cipher"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/cipher", "cipher", map[string]interface{}{
+		"AEAD":                pry.Type((*cipher.AEAD)(nil)).Elem(),
+		"Block":               pry.Type((*cipher.Block)(nil)).Elem(),
+		"BlockMode":           pry.Type((*cipher.BlockMode)(nil)).Elem(),
+		"NewCBCDecrypter":     cipher.NewCBCDecrypter,
+		"NewCBCEncrypter":     cipher.NewCBCEncrypter,
+		"NewCFBDecrypter":     cipher.NewCFBDecrypter,
+		"NewCFBEncrypter":     cipher.NewCFBEncrypter,
+		"NewCTR":              cipher.NewCTR,
+		"NewGCM":              cipher.NewGCM,
+		"NewGCMWithNonceSize": cipher.NewGCMWithNonceSize,
+		"NewGCMWithTagSize":   cipher.NewGCMWithTagSize,
+		"NewOFB":              cipher.NewOFB,
+		"Stream":              pry.Type((*cipher.Stream)(nil)).Elem(),
+		"StreamReader":        pry.Type((*cipher.StreamReader)(nil)).Elem(),
+		"StreamWriter":        pry.Type((*cipher.StreamWriter)(nil)).Elem(),
+	})
+}
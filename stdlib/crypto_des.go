@@ -0,0 +1,17 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/des"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/des", "des", map[string]interface{}{
+		"BlockSize":          des.BlockSize,
+		"KeySizeError":       pry.Type((*des.KeySizeError)(nil)).Elem(),
+		"NewCipher":          des.NewCipher,
+		"NewTripleDESCipher": des.NewTripleDESCipher,
+	})
+}
@@ -0,0 +1,26 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/dsa"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/dsa", "dsa", map[string]interface{}{
+		"ErrInvalidPublicKey": &dsa.ErrInvalidPublicKey,
+		"GenerateKey":         dsa.GenerateKey,
+		"GenerateParameters":  dsa.GenerateParameters,
+		"L1024N160":           dsa.L1024N160,
+		"L2048N224":           dsa.L2048N224,
+		"L2048N256":           dsa.L2048N256,
+		"L3072N256":           dsa.L3072N256,
+		"ParameterSizes":      pry.Type((*dsa.ParameterSizes)(nil)).Elem(),
+		"Parameters":          pry.Type((*dsa.Parameters)(nil)).Elem(),
+		"PrivateKey":          pry.Type((*dsa.PrivateKey)(nil)).Elem(),
+		"PublicKey":           pry.Type((*dsa.PublicKey)(nil)).Elem(),
+		"Sign":                dsa.Sign,
+		"Verify":              dsa.Verify,
+	})
+}
@@ -0,0 +1,20 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/ecdsa"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/ecdsa", "ecdsa", map[string]interface{}{
+		"GenerateKey": ecdsa.GenerateKey,
+		"PrivateKey":  pry.Type((*ecdsa.PrivateKey)(nil)).Elem(),
+		"PublicKey":   pry.Type((*ecdsa.PublicKey)(nil)).Elem(),
+		"Sign":        ecdsa.Sign,
+		"SignASN1":    ecdsa.SignASN1,
+		"Verify":      ecdsa.Verify,
+		"VerifyASN1":  ecdsa.VerifyASN1,
+	})
+}
@@ -0,0 +1,24 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/elliptic"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/elliptic", "elliptic", map[string]interface{}{
+		"Curve":               pry.Type((*elliptic.Curve)(nil)).Elem(),
+		"CurveParams":         pry.Type((*elliptic.CurveParams)(nil)).Elem(),
+		"GenerateKey":         elliptic.GenerateKey,
+		"Marshal":             elliptic.Marshal,
+		"MarshalCompressed":   elliptic.MarshalCompressed,
+		"P224":                elliptic.P224,
+		"P256":                elliptic.P256,
+		"P384":                elliptic.P384,
+		"P521":                elliptic.P521,
+		"Unmarshal":           elliptic.Unmarshal,
+		"UnmarshalCompressed": elliptic.UnmarshalCompressed,
+	})
+}
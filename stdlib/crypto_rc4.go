@@ -0,0 +1,16 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/rc4"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/rc4", "rc4", map[string]interface{}{
+		"Cipher":       pry.Type((*rc4.Cipher)(nil)).Elem(),
+		"KeySizeError": pry.Type((*rc4.KeySizeError)(nil)).Elem(),
+		"NewCipher":    rc4.NewCipher,
+	})
+}
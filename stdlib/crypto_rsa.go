@@ -0,0 +1,36 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/rsa"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/rsa", "rsa", map[string]interface{}{
+		"CRTValue":                  pry.Type((*rsa.CRTValue)(nil)).Elem(),
+		"DecryptOAEP":               rsa.DecryptOAEP,
+		"DecryptPKCS1v15":           rsa.DecryptPKCS1v15,
+		"DecryptPKCS1v15SessionKey": rsa.DecryptPKCS1v15SessionKey,
+		"EncryptOAEP":               rsa.EncryptOAEP,
+		"EncryptPKCS1v15":           rsa.EncryptPKCS1v15,
+		"ErrDecryption":             &rsa.ErrDecryption,
+		"ErrMessageTooLong":         &rsa.ErrMessageTooLong,
+		"ErrVerification":           &rsa.ErrVerification,
+		"GenerateKey":               rsa.GenerateKey,
+		"GenerateMultiPrimeKey":     rsa.GenerateMultiPrimeKey,
+		"OAEPOptions":               pry.Type((*rsa.OAEPOptions)(nil)).Elem(),
+		"PKCS1v15DecryptOptions":    pry.Type((*rsa.PKCS1v15DecryptOptions)(nil)).Elem(),
+		"PSSOptions":                pry.Type((*rsa.PSSOptions)(nil)).Elem(),
+		"PSSSaltLengthAuto":         rsa.PSSSaltLengthAuto,
+		"PSSSaltLengthEqualsHash":   rsa.PSSSaltLengthEqualsHash,
+		"PrecomputedValues":         pry.Type((*rsa.PrecomputedValues)(nil)).Elem(),
+		"PrivateKey":                pry.Type((*rsa.PrivateKey)(nil)).Elem(),
+		"PublicKey":                 pry.Type((*rsa.PublicKey)(nil)).Elem(),
+		"SignPKCS1v15":              rsa.SignPKCS1v15,
+		"SignPSS":                   rsa.SignPSS,
+		"VerifyPKCS1v15":            rsa.VerifyPKCS1v15,
+		"VerifyPSS":                 rsa.VerifyPSS,
+	})
+}
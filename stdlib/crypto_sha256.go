@@ -0,0 +1,20 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/sha256"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/sha256", "sha256", map[string]interface{}{
+		"BlockSize": sha256.BlockSize,
+		"New":       sha256.New,
+		"New224":    sha256.New224,
+		"Size":      sha256.Size,
+		"Size224":   sha256.Size224,
+		"Sum224":    sha256.Sum224,
+		"Sum256":    sha256.Sum256,
+	})
+}
@@ -0,0 +1,26 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/sha512"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/sha512", "sha512", map[string]interface{}{
+		"BlockSize":  sha512.BlockSize,
+		"New":        sha512.New,
+		"New384":     sha512.New384,
+		"New512_224": sha512.New512_224,
+		"New512_256": sha512.New512_256,
+		"Size":       sha512.Size,
+		"Size224":    sha512.Size224,
+		"Size256":    sha512.Size256,
+		"Size384":    sha512.Size384,
+		"Sum384":     sha512.Sum384,
+		"Sum512":     sha512.Sum512,
+		"Sum512_224": sha512.Sum512_224,
+		"Sum512_256": sha512.Sum512_256,
+	})
+}
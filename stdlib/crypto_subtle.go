@@ -0,0 +1,19 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/subtle"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/subtle", "subtle", map[string]interface{}{
+		"ConstantTimeByteEq":   subtle.ConstantTimeByteEq,
+		"ConstantTimeCompare":  subtle.ConstantTimeCompare,
+		"ConstantTimeCopy":     subtle.ConstantTimeCopy,
+		"ConstantTimeEq":       subtle.ConstantTimeEq,
+		"ConstantTimeLessOrEq": subtle.ConstantTimeLessOrEq,
+		"ConstantTimeSelect":   subtle.ConstantTimeSelect,
+	})
+}
@@ -0,0 +1,97 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/tls"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/tls", "tls", map[string]interface{}{
+		"Certificate":                          pry.Type((*tls.Certificate)(nil)).Elem(),
+		"CertificateRequestInfo":               pry.Type((*tls.CertificateRequestInfo)(nil)).Elem(),
+		"CipherSuite":                          pry.Type((*tls.CipherSuite)(nil)).Elem(),
+		"CipherSuiteName":                      tls.CipherSuiteName,
+		"CipherSuites":                         tls.CipherSuites,
+		"Client":                               tls.Client,
+		"ClientAuthType":                       pry.Type((*tls.ClientAuthType)(nil)).Elem(),
+		"ClientHelloInfo":                      pry.Type((*tls.ClientHelloInfo)(nil)).Elem(),
+		"ClientSessionCache":                   pry.Type((*tls.ClientSessionCache)(nil)).Elem(),
+		"ClientSessionState":                   pry.Type((*tls.ClientSessionState)(nil)).Elem(),
+		"Config":                               pry.Type((*tls.Config)(nil)).Elem(),
+		"Conn":                                 pry.Type((*tls.Conn)(nil)).Elem(),
+		"ConnectionState":                      pry.Type((*tls.ConnectionState)(nil)).Elem(),
+		"CurveID":                              pry.Type((*tls.CurveID)(nil)).Elem(),
+		"CurveP256":                            tls.CurveP256,
+		"CurveP384":                            tls.CurveP384,
+		"CurveP521":                            tls.CurveP521,
+		"Dial":                                 tls.Dial,
+		"DialWithDialer":                       tls.DialWithDialer,
+		"Dialer":                               pry.Type((*tls.Dialer)(nil)).Elem(),
+		"ECDSAWithP256AndSHA256":               tls.ECDSAWithP256AndSHA256,
+		"ECDSAWithP384AndSHA384":               tls.ECDSAWithP384AndSHA384,
+		"ECDSAWithP521AndSHA512":               tls.ECDSAWithP521AndSHA512,
+		"ECDSAWithSHA1":                        tls.ECDSAWithSHA1,
+		"Ed25519":                              tls.Ed25519,
+		"InsecureCipherSuites":                 tls.InsecureCipherSuites,
+		"Listen":                               tls.Listen,
+		"LoadX509KeyPair":                      tls.LoadX509KeyPair,
+		"NewLRUClientSessionCache":             tls.NewLRUClientSessionCache,
+		"NewListener":                          tls.NewListener,
+		"NoClientCert":                         tls.NoClientCert,
+		"PKCS1WithSHA1":                        tls.PKCS1WithSHA1,
+		"PKCS1WithSHA256":                      tls.PKCS1WithSHA256,
+		"PKCS1WithSHA384":                      tls.PKCS1WithSHA384,
+		"PKCS1WithSHA512":                      tls.PKCS1WithSHA512,
+		"PSSWithSHA256":                        tls.PSSWithSHA256,
+		"PSSWithSHA384":                        tls.PSSWithSHA384,
+		"PSSWithSHA512":                        tls.PSSWithSHA512,
+		"RecordHeaderError":                    pry.Type((*tls.RecordHeaderError)(nil)).Elem(),
+		"RenegotiateFreelyAsClient":            tls.RenegotiateFreelyAsClient,
+		"RenegotiateNever":                     tls.RenegotiateNever,
+		"RenegotiateOnceAsClient":              tls.RenegotiateOnceAsClient,
+		"RenegotiationSupport":                 pry.Type((*tls.RenegotiationSupport)(nil)).Elem(),
+		"RequestClientCert":                    tls.RequestClientCert,
+		"RequireAndVerifyClientCert":           tls.RequireAndVerifyClientCert,
+		"RequireAnyClientCert":                 tls.RequireAnyClientCert,
+		"Server":                               tls.Server,
+		"SignatureScheme":                      pry.Type((*tls.SignatureScheme)(nil)).Elem(),
+		"TLS_AES_128_GCM_SHA256":               tls.TLS_AES_128_GCM_SHA256,
+		"TLS_AES_256_GCM_SHA384":               tls.TLS_AES_256_GCM_SHA384,
+		"TLS_CHACHA20_POLY1305_SHA256":         tls.TLS_CHACHA20_POLY1305_SHA256,
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA": tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256":       tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256":       tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":          tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384":       tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":        tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+		"TLS_ECDHE_ECDSA_WITH_RC4_128_SHA":              tls.TLS_ECDHE_ECDSA_WITH_RC4_128_SHA,
+		"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA":           tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA,
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":            tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256":         tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":            tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":          tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256":   tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		"TLS_ECDHE_RSA_WITH_RC4_128_SHA":                tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA,
+		"TLS_FALLBACK_SCSV":                             tls.TLS_FALLBACK_SCSV,
+		"TLS_RSA_WITH_3DES_EDE_CBC_SHA":                 tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
+		"TLS_RSA_WITH_AES_128_CBC_SHA":                  tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+		"TLS_RSA_WITH_AES_128_CBC_SHA256":               tls.TLS_RSA_WITH_AES_128_CBC_SHA256,
+		"TLS_RSA_WITH_AES_128_GCM_SHA256":               tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+		"TLS_RSA_WITH_AES_256_CBC_SHA":                  tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+		"TLS_RSA_WITH_AES_256_GCM_SHA384":               tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+		"TLS_RSA_WITH_RC4_128_SHA":                      tls.TLS_RSA_WITH_RC4_128_SHA,
+		"VerifyClientCertIfGiven":                       tls.VerifyClientCertIfGiven,
+		"VersionSSL30":                                  tls.VersionSSL30,
+		"VersionTLS10":                                  tls.VersionTLS10,
+		"VersionTLS11":                                  tls.VersionTLS11,
+		"VersionTLS12":                                  tls.VersionTLS12,
+		"VersionTLS13":                                  tls.VersionTLS13,
+		"X25519":                                        tls.X25519,
+		"X509KeyPair":                                   tls.X509KeyPair,
+	})
+}
@@ -0,0 +1,116 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"crypto/x509"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("crypto/x509", "x509", map[string]interface{}{
+		"CANotAuthorizedForExtKeyUsage": x509.CANotAuthorizedForExtKeyUsage,
+		"CANotAuthorizedForThisName":    x509.CANotAuthorizedForThisName,
+		"CertPool":                      pry.Type((*x509.CertPool)(nil)).Elem(),
+		"Certificate":                   pry.Type((*x509.Certificate)(nil)).Elem(),
+		"CertificateInvalidError":       pry.Type((*x509.CertificateInvalidError)(nil)).Elem(),
+		"CertificateRequest":            pry.Type((*x509.CertificateRequest)(nil)).Elem(),
+		"ConstraintViolationError":      pry.Type((*x509.ConstraintViolationError)(nil)).Elem(),
+		"CreateCertificate":             x509.CreateCertificate,
+		"CreateCertificateRequest":      x509.CreateCertificateRequest,
+		"CreateRevocationList":          x509.CreateRevocationList,
+		"DSA":                           x509.DSA,
+		"DSAWithSHA1":                   x509.DSAWithSHA1,
+		"DSAWithSHA256":                 x509.DSAWithSHA256,
+		"DecryptPEMBlock":               x509.DecryptPEMBlock,
+		"ECDSA":                         x509.ECDSA,
+		"ECDSAWithSHA1":                 x509.ECDSAWithSHA1,
+		"ECDSAWithSHA256":               x509.ECDSAWithSHA256,
+		"ECDSAWithSHA384":               x509.ECDSAWithSHA384,
+		"ECDSAWithSHA512":               x509.ECDSAWithSHA512,
+		"Ed25519":                       x509.Ed25519,
+		"EncryptPEMBlock":               x509.EncryptPEMBlock,
+		"ErrUnsupportedAlgorithm":       &x509.ErrUnsupportedAlgorithm,
+		"Expired":                       x509.Expired,
+		"ExtKeyUsage":                   pry.Type((*x509.ExtKeyUsage)(nil)).Elem(),
+		"ExtKeyUsageAny":                x509.ExtKeyUsageAny,
+		"ExtKeyUsageClientAuth":         x509.ExtKeyUsageClientAuth,
+		"ExtKeyUsageCodeSigning":        x509.ExtKeyUsageCodeSigning,
+		"ExtKeyUsageEmailProtection":    x509.ExtKeyUsageEmailProtection,
+		"ExtKeyUsageIPSECEndSystem":     x509.ExtKeyUsageIPSECEndSystem,
+		"ExtKeyUsageIPSECTunnel":        x509.ExtKeyUsageIPSECTunnel,
+		"ExtKeyUsageIPSECUser":          x509.ExtKeyUsageIPSECUser,
+		"ExtKeyUsageMicrosoftCommercialCodeSigning": x509.ExtKeyUsageMicrosoftCommercialCodeSigning,
+		"ExtKeyUsageMicrosoftKernelCodeSigning":     x509.ExtKeyUsageMicrosoftKernelCodeSigning,
+		"ExtKeyUsageMicrosoftServerGatedCrypto":     x509.ExtKeyUsageMicrosoftServerGatedCrypto,
+		"ExtKeyUsageNetscapeServerGatedCrypto":      x509.ExtKeyUsageNetscapeServerGatedCrypto,
+		"ExtKeyUsageOCSPSigning":                    x509.ExtKeyUsageOCSPSigning,
+		"ExtKeyUsageServerAuth":                     x509.ExtKeyUsageServerAuth,
+		"ExtKeyUsageTimeStamping":                   x509.ExtKeyUsageTimeStamping,
+		"HostnameError":                             pry.Type((*x509.HostnameError)(nil)).Elem(),
+		"IncompatibleUsage":                         x509.IncompatibleUsage,
+		"IncorrectPasswordError":                    &x509.IncorrectPasswordError,
+		"InsecureAlgorithmError":                    pry.Type((*x509.InsecureAlgorithmError)(nil)).Elem(),
+		"InvalidReason":                             pry.Type((*x509.InvalidReason)(nil)).Elem(),
+		"IsEncryptedPEMBlock":                       x509.IsEncryptedPEMBlock,
+		"KeyUsage":                                  pry.Type((*x509.KeyUsage)(nil)).Elem(),
+		"KeyUsageCRLSign":                           x509.KeyUsageCRLSign,
+		"KeyUsageCertSign":                          x509.KeyUsageCertSign,
+		"KeyUsageContentCommitment":                 x509.KeyUsageContentCommitment,
+		"KeyUsageDataEncipherment":                  x509.KeyUsageDataEncipherment,
+		"KeyUsageDecipherOnly":                      x509.KeyUsageDecipherOnly,
+		"KeyUsageDigitalSignature":                  x509.KeyUsageDigitalSignature,
+		"KeyUsageEncipherOnly":                      x509.KeyUsageEncipherOnly,
+		"KeyUsageKeyAgreement":                      x509.KeyUsageKeyAgreement,
+		"KeyUsageKeyEncipherment":                   x509.KeyUsageKeyEncipherment,
+		"MD2WithRSA":                                x509.MD2WithRSA,
+		"MD5WithRSA":                                x509.MD5WithRSA,
+		"MarshalECPrivateKey":                       x509.MarshalECPrivateKey,
+		"MarshalPKCS1PrivateKey":                    x509.MarshalPKCS1PrivateKey,
+		"MarshalPKCS1PublicKey":                     x509.MarshalPKCS1PublicKey,
+		"MarshalPKCS8PrivateKey":                    x509.MarshalPKCS8PrivateKey,
+		"MarshalPKIXPublicKey":                      x509.MarshalPKIXPublicKey,
+		"NameConstraintsWithoutSANs":                x509.NameConstraintsWithoutSANs,
+		"NameMismatch":                              x509.NameMismatch,
+		"NewCertPool":                               x509.NewCertPool,
+		"NotAuthorizedToSign":                       x509.NotAuthorizedToSign,
+		"PEMCipher":                                 pry.Type((*x509.PEMCipher)(nil)).Elem(),
+		"PEMCipher3DES":                             x509.PEMCipher3DES,
+		"PEMCipherAES128":                           x509.PEMCipherAES128,
+		"PEMCipherAES192":                           x509.PEMCipherAES192,
+		"PEMCipherAES256":                           x509.PEMCipherAES256,
+		"PEMCipherDES":                              x509.PEMCipherDES,
+		"ParseCRL":                                  x509.ParseCRL,
+		"ParseCertificate":                          x509.ParseCertificate,
+		"ParseCertificateRequest":                   x509.ParseCertificateRequest,
+		"ParseCertificates":                         x509.ParseCertificates,
+		"ParseDERCRL":                               x509.ParseDERCRL,
+		"ParseECPrivateKey":                         x509.ParseECPrivateKey,
+		"ParsePKCS1PrivateKey":                      x509.ParsePKCS1PrivateKey,
+		"ParsePKCS1PublicKey":                       x509.ParsePKCS1PublicKey,
+		"ParsePKCS8PrivateKey":                      x509.ParsePKCS8PrivateKey,
+		"ParsePKIXPublicKey":                        x509.ParsePKIXPublicKey,
+		"PublicKeyAlgorithm":                        pry.Type((*x509.PublicKeyAlgorithm)(nil)).Elem(),
+		"PureEd25519":                               x509.PureEd25519,
+		"RSA":                                       x509.RSA,
+		"RevocationList":                            pry.Type((*x509.RevocationList)(nil)).Elem(),
+		"SHA1WithRSA":                               x509.SHA1WithRSA,
+		"SHA256WithRSA":                             x509.SHA256WithRSA,
+		"SHA256WithRSAPSS":                          x509.SHA256WithRSAPSS,
+		"SHA384WithRSA":                             x509.SHA384WithRSA,
+		"SHA384WithRSAPSS":                          x509.SHA384WithRSAPSS,
+		"SHA512WithRSA":                             x509.SHA512WithRSA,
+		"SHA512WithRSAPSS":                          x509.SHA512WithRSAPSS,
+		"SignatureAlgorithm":                        pry.Type((*x509.SignatureAlgorithm)(nil)).Elem(),
+		"SystemCertPool":                            x509.SystemCertPool,
+		"SystemRootsError":                          pry.Type((*x509.SystemRootsError)(nil)).Elem(),
+		"TooManyConstraints":                        x509.TooManyConstraints,
+		"TooManyIntermediates":                      x509.TooManyIntermediates,
+		"UnconstrainedName":                         x509.UnconstrainedName,
+		"UnhandledCriticalExtension":                pry.Type((*x509.UnhandledCriticalExtension)(nil)).Elem(),
+		"UnknownAuthorityError":                     pry.Type((*x509.UnknownAuthorityError)(nil)).Elem(),
+		"UnknownPublicKeyAlgorithm":                 x509.UnknownPublicKeyAlgorithm,
+		"UnknownSignatureAlgorithm":                 x509.UnknownSignatureAlgorithm,
+		"VerifyOptions":                             pry.Type((*x509.VerifyOptions)(nil)).Elem(),
+	})
+}
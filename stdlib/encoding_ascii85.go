@@ -0,0 +1,19 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/ascii85"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/ascii85", "ascii85", map[string]interface{}{
+		"CorruptInputError": pry.Type((*ascii85.CorruptInputError)(nil)).Elem(),
+		"Decode":            ascii85.Decode,
+		"Encode":            ascii85.Encode,
+		"MaxEncodedLen":     ascii85.MaxEncodedLen,
+		"NewDecoder":        ascii85.NewDecoder,
+		"NewEncoder":        ascii85.NewEncoder,
+	})
+}
@@ -0,0 +1,49 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/asn1"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/asn1", "asn1", map[string]interface{}{
+		"BitString":            pry.Type((*asn1.BitString)(nil)).Elem(),
+		"ClassApplication":     asn1.ClassApplication,
+		"ClassContextSpecific": asn1.ClassContextSpecific,
+		"ClassPrivate":         asn1.ClassPrivate,
+		"ClassUniversal":       asn1.ClassUniversal,
+		"Enumerated":           pry.Type((*asn1.Enumerated)(nil)).Elem(),
+		"Flag":                 pry.Type((*asn1.Flag)(nil)).Elem(),
+		"Marshal":              asn1.Marshal,
+		"MarshalWithParams":    asn1.MarshalWithParams,
+		"NullBytes":            &asn1.NullBytes,
+		"NullRawValue":         &asn1.NullRawValue,
+		"ObjectIdentifier":     pry.Type((*asn1.ObjectIdentifier)(nil)).Elem(),
+		"RawContent":           pry.Type((*asn1.RawContent)(nil)).Elem(),
+		"RawValue":             pry.Type((*asn1.RawValue)(nil)).Elem(),
+		"StructuralError":      pry.Type((*asn1.StructuralError)(nil)).Elem(),
+		"SyntaxError":          pry.Type((*asn1.SyntaxError)(nil)).Elem(),
+		"TagBMPString":         asn1.TagBMPString,
+		"TagBitString":         asn1.TagBitString,
+		"TagBoolean":           asn1.TagBoolean,
+		"TagEnum":              asn1.TagEnum,
+		"TagGeneralString":     asn1.TagGeneralString,
+		"TagGeneralizedTime":   asn1.TagGeneralizedTime,
+		"TagIA5String":         asn1.TagIA5String,
+		"TagInteger":           asn1.TagInteger,
+		"TagNull":              asn1.TagNull,
+		"TagNumericString":     asn1.TagNumericString,
+		"TagOID":               asn1.TagOID,
+		"TagOctetString":       asn1.TagOctetString,
+		"TagPrintableString":   asn1.TagPrintableString,
+		"TagSequence":          asn1.TagSequence,
+		"TagSet":               asn1.TagSet,
+		"TagT61String":         asn1.TagT61String,
+		"TagUTCTime":           asn1.TagUTCTime,
+		"TagUTF8String":        asn1.TagUTF8String,
+		"Unmarshal":            asn1.Unmarshal,
+		"UnmarshalWithParams":  asn1.UnmarshalWithParams,
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/base32"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/base32", "base32", map[string]interface{}{
+		"CorruptInputError": pry.Type((*base32.CorruptInputError)(nil)).Elem(),
+		"Encoding":          pry.Type((*base32.Encoding)(nil)).Elem(),
+		"HexEncoding":       &base32.HexEncoding,
+		"NewDecoder":        base32.NewDecoder,
+		"NewEncoder":        base32.NewEncoder,
+		"NewEncoding":       base32.NewEncoding,
+		"NoPadding":         base32.NoPadding,
+		"StdEncoding":       &base32.StdEncoding,
+		"StdPadding":        base32.StdPadding,
+	})
+}
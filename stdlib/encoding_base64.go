@@ -0,0 +1,24 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/base64"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/base64", "base64", map[string]interface{}{
+		"CorruptInputError": pry.Type((*base64.CorruptInputError)(nil)).Elem(),
+		"Encoding":          pry.Type((*base64.Encoding)(nil)).Elem(),
+		"NewDecoder":        base64.NewDecoder,
+		"NewEncoder":        base64.NewEncoder,
+		"NewEncoding":       base64.NewEncoding,
+		"NoPadding":         base64.NoPadding,
+		"RawStdEncoding":    &base64.RawStdEncoding,
+		"RawURLEncoding":    &base64.RawURLEncoding,
+		"StdEncoding":       &base64.StdEncoding,
+		"StdPadding":        base64.StdPadding,
+		"URLEncoding":       &base64.URLEncoding,
+	})
+}
@@ -0,0 +1,28 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/binary"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/binary", "binary", map[string]interface{}{
+		"BigEndian":      &binary.BigEndian,
+		"ByteOrder":      pry.Type((*binary.ByteOrder)(nil)).Elem(),
+		"LittleEndian":   &binary.LittleEndian,
+		"MaxVarintLen16": binary.MaxVarintLen16,
+		"MaxVarintLen32": binary.MaxVarintLen32,
+		"MaxVarintLen64": binary.MaxVarintLen64,
+		"PutUvarint":     binary.PutUvarint,
+		"PutVarint":      binary.PutVarint,
+		"Read":           binary.Read,
+		"ReadUvarint":    binary.ReadUvarint,
+		"ReadVarint":     binary.ReadVarint,
+		"Size":           binary.Size,
+		"Uvarint":        binary.Uvarint,
+		"Varint":         binary.Varint,
+		"Write":          binary.Write,
+	})
+}
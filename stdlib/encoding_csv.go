@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/csv"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/csv", "csv", map[string]interface{}{
+		"ErrBareQuote":     &csv.ErrBareQuote,
+		"ErrFieldCount":    &csv.ErrFieldCount,
+		"ErrQuote":         &csv.ErrQuote,
+		"ErrTrailingComma": &csv.ErrTrailingComma,
+		"NewReader":        csv.NewReader,
+		"NewWriter":        csv.NewWriter,
+		"ParseError":       pry.Type((*csv.ParseError)(nil)).Elem(),
+		"Reader":           pry.Type((*csv.Reader)(nil)).Elem(),
+		"Writer":           pry.Type((*csv.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/gob"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/gob", "gob", map[string]interface{}{
+		"CommonType":   pry.Type((*gob.CommonType)(nil)).Elem(),
+		"Decoder":      pry.Type((*gob.Decoder)(nil)).Elem(),
+		"Encoder":      pry.Type((*gob.Encoder)(nil)).Elem(),
+		"GobDecoder":   pry.Type((*gob.GobDecoder)(nil)).Elem(),
+		"GobEncoder":   pry.Type((*gob.GobEncoder)(nil)).Elem(),
+		"NewDecoder":   gob.NewDecoder,
+		"NewEncoder":   gob.NewEncoder,
+		"Register":     gob.Register,
+		"RegisterName": gob.RegisterName,
+	})
+}
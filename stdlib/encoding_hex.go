@@ -0,0 +1,25 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/hex"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/hex", "hex", map[string]interface{}{
+		"Decode":           hex.Decode,
+		"DecodeString":     hex.DecodeString,
+		"DecodedLen":       hex.DecodedLen,
+		"Dump":             hex.Dump,
+		"Dumper":           hex.Dumper,
+		"Encode":           hex.Encode,
+		"EncodeToString":   hex.EncodeToString,
+		"EncodedLen":       hex.EncodedLen,
+		"ErrLength":        &hex.ErrLength,
+		"InvalidByteError": pry.Type((*hex.InvalidByteError)(nil)).Elem(),
+		"NewDecoder":       hex.NewDecoder,
+		"NewEncoder":       hex.NewEncoder,
+	})
+}
@@ -0,0 +1,38 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/json"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/json", "json", map[string]interface{}{
+		"Compact":               json.Compact,
+		"Decoder":               pry.Type((*json.Decoder)(nil)).Elem(),
+		"Delim":                 pry.Type((*json.Delim)(nil)).Elem(),
+		"Encoder":               pry.Type((*json.Encoder)(nil)).Elem(),
+		"HTMLEscape":            json.HTMLEscape,
+		"Indent":                json.Indent,
+		"InvalidUTF8Error":      pry.Type((*json.InvalidUTF8Error)(nil)).Elem(),
+		"InvalidUnmarshalError": pry.Type((*json.InvalidUnmarshalError)(nil)).Elem(),
+		"Marshal":               json.Marshal,
+		"MarshalIndent":         json.MarshalIndent,
+		"Marshaler":             pry.Type((*json.Marshaler)(nil)).Elem(),
+		"MarshalerError":        pry.Type((*json.MarshalerError)(nil)).Elem(),
+		"NewDecoder":            json.NewDecoder,
+		"NewEncoder":            json.NewEncoder,
+		"Number":                pry.Type((*json.Number)(nil)).Elem(),
+		"RawMessage":            pry.Type((*json.RawMessage)(nil)).Elem(),
+		"SyntaxError":           pry.Type((*json.SyntaxError)(nil)).Elem(),
+		"Token":                 pry.Type((*json.Token)(nil)).Elem(),
+		"Unmarshal":             json.Unmarshal,
+		"UnmarshalFieldError":   pry.Type((*json.UnmarshalFieldError)(nil)).Elem(),
+		"UnmarshalTypeError":    pry.Type((*json.UnmarshalTypeError)(nil)).Elem(),
+		"Unmarshaler":           pry.Type((*json.Unmarshaler)(nil)).Elem(),
+		"UnsupportedTypeError":  pry.Type((*json.UnsupportedTypeError)(nil)).Elem(),
+		"UnsupportedValueError": pry.Type((*json.UnsupportedValueError)(nil)).Elem(),
+		"Valid":                 json.Valid,
+	})
+}
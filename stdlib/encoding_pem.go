@@ -0,0 +1,17 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/pem"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/pem", "pem", map[string]interface{}{
+		"Block":          pry.Type((*pem.Block)(nil)).Elem(),
+		"Decode":         pem.Decode,
+		"Encode":         pem.Encode,
+		"EncodeToMemory": pem.EncodeToMemory,
+	})
+}
@@ -0,0 +1,45 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"encoding/xml"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("encoding/xml", "xml", map[string]interface{}{
+		"Attr":                 pry.Type((*xml.Attr)(nil)).Elem(),
+		"CharData":             pry.Type((*xml.CharData)(nil)).Elem(),
+		"Comment":              pry.Type((*xml.Comment)(nil)).Elem(),
+		"CopyToken":            xml.CopyToken,
+		"Decoder":              pry.Type((*xml.Decoder)(nil)).Elem(),
+		"Directive":            pry.Type((*xml.Directive)(nil)).Elem(),
+		"Encoder":              pry.Type((*xml.Encoder)(nil)).Elem(),
+		"EndElement":           pry.Type((*xml.EndElement)(nil)).Elem(),
+		"Escape":               xml.Escape,
+		"EscapeText":           xml.EscapeText,
+		"HTMLAutoClose":        &xml.HTMLAutoClose,
+		"HTMLEntity":           &xml.HTMLEntity,
+		"Header":               xml.Header,
+		"Marshal":              xml.Marshal,
+		"MarshalIndent":        xml.MarshalIndent,
+		"Marshaler":            pry.Type((*xml.Marshaler)(nil)).Elem(),
+		"MarshalerAttr":        pry.Type((*xml.MarshalerAttr)(nil)).Elem(),
+		"Name":                 pry.Type((*xml.Name)(nil)).Elem(),
+		"NewDecoder":           xml.NewDecoder,
+		"NewEncoder":           xml.NewEncoder,
+		"NewTokenDecoder":      xml.NewTokenDecoder,
+		"ProcInst":             pry.Type((*xml.ProcInst)(nil)).Elem(),
+		"StartElement":         pry.Type((*xml.StartElement)(nil)).Elem(),
+		"SyntaxError":          pry.Type((*xml.SyntaxError)(nil)).Elem(),
+		"TagPathError":         pry.Type((*xml.TagPathError)(nil)).Elem(),
+		"Token":                pry.Type((*xml.Token)(nil)).Elem(),
+		"TokenReader":          pry.Type((*xml.TokenReader)(nil)).Elem(),
+		"Unmarshal":            xml.Unmarshal,
+		"UnmarshalError":       pry.Type((*xml.UnmarshalError)(nil)).Elem(),
+		"Unmarshaler":          pry.Type((*xml.Unmarshaler)(nil)).Elem(),
+		"UnmarshalerAttr":      pry.Type((*xml.UnmarshalerAttr)(nil)).Elem(),
+		"UnsupportedTypeError": pry.Type((*xml.UnsupportedTypeError)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,28 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"expvar"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("expvar", "expvar", map[string]interface{}{
+		"Do":        expvar.Do,
+		"Float":     pry.Type((*expvar.Float)(nil)).Elem(),
+		"Func":      pry.Type((*expvar.Func)(nil)).Elem(),
+		"Get":       expvar.Get,
+		"Handler":   expvar.Handler,
+		"Int":       pry.Type((*expvar.Int)(nil)).Elem(),
+		"KeyValue":  pry.Type((*expvar.KeyValue)(nil)).Elem(),
+		"Map":       pry.Type((*expvar.Map)(nil)).Elem(),
+		"NewFloat":  expvar.NewFloat,
+		"NewInt":    expvar.NewInt,
+		"NewMap":    expvar.NewMap,
+		"NewString": expvar.NewString,
+		"Publish":   expvar.Publish,
+		"String":    pry.Type((*expvar.String)(nil)).Elem(),
+		"Var":       pry.Type((*expvar.Var)(nil)).Elem(),
+	})
+}
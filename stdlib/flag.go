@@ -0,0 +1,55 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"flag"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("flag", "flag", map[string]interface{}{
+		"Arg":             flag.Arg,
+		"Args":            flag.Args,
+		"Bool":            flag.Bool,
+		"BoolVar":         flag.BoolVar,
+		"CommandLine":     &flag.CommandLine,
+		"ContinueOnError": flag.ContinueOnError,
+		"Duration":        flag.Duration,
+		"DurationVar":     flag.DurationVar,
+		"ErrHelp":         &flag.ErrHelp,
+		"ErrorHandling":   pry.Type((*flag.ErrorHandling)(nil)).Elem(),
+		"ExitOnError":     flag.ExitOnError,
+		"Flag":            pry.Type((*flag.Flag)(nil)).Elem(),
+		"FlagSet":         pry.Type((*flag.FlagSet)(nil)).Elem(),
+		"Float64":         flag.Float64,
+		"Float64Var":      flag.Float64Var,
+		"Func":            flag.Func,
+		"Getter":          pry.Type((*flag.Getter)(nil)).Elem(),
+		"Int":             flag.Int,
+		"Int64":           flag.Int64,
+		"Int64Var":        flag.Int64Var,
+		"IntVar":          flag.IntVar,
+		"Lookup":          flag.Lookup,
+		"NArg":            flag.NArg,
+		"NFlag":           flag.NFlag,
+		"NewFlagSet":      flag.NewFlagSet,
+		"PanicOnError":    flag.PanicOnError,
+		"Parse":           flag.Parse,
+		"Parsed":          flag.Parsed,
+		"PrintDefaults":   flag.PrintDefaults,
+		"Set":             flag.Set,
+		"String":          flag.String,
+		"StringVar":       flag.StringVar,
+		"Uint":            flag.Uint,
+		"Uint64":          flag.Uint64,
+		"Uint64Var":       flag.Uint64Var,
+		"UintVar":         flag.UintVar,
+		"UnquoteUsage":    flag.UnquoteUsage,
+		"Usage":           &flag.Usage,
+		"Value":           pry.Type((*flag.Value)(nil)).Elem(),
+		"Var":             flag.Var,
+		"Visit":           flag.Visit,
+		"VisitAll":        flag.VisitAll,
+	})
+}
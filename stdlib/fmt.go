@@ -0,0 +1,38 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"fmt"
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func init() {
+	pry.RegisterPackage("fmt", "fmt", map[string]interface{}{
+		"Errorf":     fmt.Errorf,
+		"Formatter":  pry.Type((*fmt.Formatter)(nil)).Elem(),
+		"Fprint":     fmt.Fprint,
+		"Fprintf":    fmt.Fprintf,
+		"Fprintln":   fmt.Fprintln,
+		"Fscan":      fmt.Fscan,
+		"Fscanf":     fmt.Fscanf,
+		"Fscanln":    fmt.Fscanln,
+		"GoStringer": pry.Type((*fmt.GoStringer)(nil)).Elem(),
+		"Print":      fmt.Print,
+		"Printf":     fmt.Printf,
+		"Println":    fmt.Println,
+		"Scan":       fmt.Scan,
+		"ScanState":  pry.Type((*fmt.ScanState)(nil)).Elem(),
+		"Scanf":      fmt.Scanf,
+		"Scanln":     fmt.Scanln,
+		"Scanner":    pry.Type((*fmt.Scanner)(nil)).Elem(),
+		"Sprint":     fmt.Sprint,
+		"Sprintf":    fmt.Sprintf,
+		"Sprintln":   fmt.Sprintln,
+		"Sscan":      fmt.Sscan,
+		"Sscanf":     fmt.Sscanf,
+		"Sscanln":    fmt.Sscanln,
+		"State":      pry.Type((*fmt.State)(nil)).Elem(),
+		"Stringer":   pry.Type((*fmt.Stringer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,133 @@
+// Command genstdlib generates the symbol tables of the stdlib package.
+//
+// It takes standard library import paths, or files listing them, and writes a
+// file per package registering its exported symbols. Only symbols of the Go
+// API up to minAPI are included, so the tables build with every Go version the
+// module supports.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/d4l3k/go-pry/pry"
+)
+
+// minAPI is the oldest Go version supported by the module.
+const minAPI = 16
+
+// constraints are the build constraints of packages that aren't available on
+// every platform. Their platform specific API is taken from linux-amd64.
+var constraints = map[string][2]string{
+	"log/syslog": {"!windows && !plan9", "!windows,!plan9"},
+}
+
+// apiLine matches the package level declarations of the api files.
+var apiLine = regexp.MustCompile(`^pkg (\S+?)(?: \(([^)]+)\))?, (?:func|var|const|type) (\w+)`)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("usage: genstdlib <packages.txt|pkgpath>...")
+	}
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func run(args []string) error {
+	var paths []string
+	for _, arg := range args {
+		if !strings.HasSuffix(arg, ".txt") {
+			paths = append(paths, arg)
+			continue
+		}
+		lines, err := readLines(arg)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, lines...)
+	}
+	api, err := readAPI()
+	if err != nil {
+		return err
+	}
+
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	for _, path := range paths {
+		pkg, err := imp.Import(path)
+		if err != nil {
+			return err
+		}
+		filtered := types.NewPackage(pkg.Path(), pkg.Name())
+		for _, name := range pkg.Scope().Names() {
+			if api[path+"."+name] {
+				filtered.Scope().Insert(pkg.Scope().Lookup(name))
+			}
+		}
+		src, err := pry.SymbolTable("stdlib", []*types.Package{filtered})
+		if err != nil {
+			return err
+		}
+		if c, ok := constraints[path]; ok {
+			src = append([]byte(fmt.Sprintf("//go:build %s\n// +build %s\n\n", c[0], c[1])), src...)
+		}
+		file := strings.Replace(path, "/", "_", -1) + ".go"
+		if err := ioutil.WriteFile(file, src, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readAPI returns the package level identifiers of the Go API up to minAPI,
+// as "path.Name".
+func readAPI() (map[string]bool, error) {
+	api := map[string]bool{}
+	for v := 0; v <= minAPI; v++ {
+		name := "go1.txt"
+		if v > 0 {
+			name = fmt.Sprintf("go1.%d.txt", v)
+		}
+		lines, err := readLines(filepath.Join(runtime.GOROOT(), "api", name))
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			m := apiLine.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			if _, ok := constraints[m[1]]; m[2] != "" && !(ok && m[2] == "linux-amd64") {
+				continue
+			}
+			api[m[1]+"."+m[3]] = true
+		}
+	}
+	return api, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, s.Err()
+}
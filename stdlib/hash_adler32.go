@@ -0,0 +1,16 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"hash/adler32"
+)
+
+func init() {
+	pry.RegisterPackage("hash/adler32", "adler32", map[string]interface{}{
+		"Checksum": adler32.Checksum,
+		"New":      adler32.New,
+		"Size":     adler32.Size,
+	})
+}
@@ -0,0 +1,25 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"hash/crc32"
+)
+
+func init() {
+	pry.RegisterPackage("hash/crc32", "crc32", map[string]interface{}{
+		"Castagnoli":   int64(crc32.Castagnoli),
+		"Checksum":     crc32.Checksum,
+		"ChecksumIEEE": crc32.ChecksumIEEE,
+		"IEEE":         int64(crc32.IEEE),
+		"IEEETable":    &crc32.IEEETable,
+		"Koopman":      int64(crc32.Koopman),
+		"MakeTable":    crc32.MakeTable,
+		"New":          crc32.New,
+		"NewIEEE":      crc32.NewIEEE,
+		"Size":         crc32.Size,
+		"Table":        pry.Type((*crc32.Table)(nil)).Elem(),
+		"Update":       crc32.Update,
+	})
+}
@@ -0,0 +1,21 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"hash/crc64"
+)
+
+func init() {
+	pry.RegisterPackage("hash/crc64", "crc64", map[string]interface{}{
+		"Checksum":  crc64.Checksum,
+		"ECMA":      uint64(crc64.ECMA),
+		"ISO":       uint64(crc64.ISO),
+		"MakeTable": crc64.MakeTable,
+		"New":       crc64.New,
+		"Size":      crc64.Size,
+		"Table":     pry.Type((*crc64.Table)(nil)).Elem(),
+		"Update":    crc64.Update,
+	})
+}
@@ -0,0 +1,15 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"html"
+)
+
+func init() {
+	pry.RegisterPackage("html", "html", map[string]interface{}{
+		"EscapeString":   html.EscapeString,
+		"UnescapeString": html.UnescapeString,
+	})
+}
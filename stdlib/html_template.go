@@ -0,0 +1,49 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"html/template"
+)
+
+func init() {
+	pry.RegisterPackage("html/template", "template", map[string]interface{}{
+		"CSS":                  pry.Type((*template.CSS)(nil)).Elem(),
+		"ErrAmbigContext":      template.ErrAmbigContext,
+		"ErrBadHTML":           template.ErrBadHTML,
+		"ErrBranchEnd":         template.ErrBranchEnd,
+		"ErrEndContext":        template.ErrEndContext,
+		"ErrNoSuchTemplate":    template.ErrNoSuchTemplate,
+		"ErrOutputContext":     template.ErrOutputContext,
+		"ErrPartialCharset":    template.ErrPartialCharset,
+		"ErrPartialEscape":     template.ErrPartialEscape,
+		"ErrPredefinedEscaper": template.ErrPredefinedEscaper,
+		"ErrRangeLoopReentry":  template.ErrRangeLoopReentry,
+		"ErrSlashAmbig":        template.ErrSlashAmbig,
+		"Error":                pry.Type((*template.Error)(nil)).Elem(),
+		"ErrorCode":            pry.Type((*template.ErrorCode)(nil)).Elem(),
+		"FuncMap":              pry.Type((*template.FuncMap)(nil)).Elem(),
+		"HTML":                 pry.Type((*template.HTML)(nil)).Elem(),
+		"HTMLAttr":             pry.Type((*template.HTMLAttr)(nil)).Elem(),
+		"HTMLEscape":           template.HTMLEscape,
+		"HTMLEscapeString":     template.HTMLEscapeString,
+		"HTMLEscaper":          template.HTMLEscaper,
+		"IsTrue":               template.IsTrue,
+		"JS":                   pry.Type((*template.JS)(nil)).Elem(),
+		"JSEscape":             template.JSEscape,
+		"JSEscapeString":       template.JSEscapeString,
+		"JSEscaper":            template.JSEscaper,
+		"JSStr":                pry.Type((*template.JSStr)(nil)).Elem(),
+		"Must":                 template.Must,
+		"New":                  template.New,
+		"OK":                   template.OK,
+		"ParseFS":              template.ParseFS,
+		"ParseFiles":           template.ParseFiles,
+		"ParseGlob":            template.ParseGlob,
+		"Srcset":               pry.Type((*template.Srcset)(nil)).Elem(),
+		"Template":             pry.Type((*template.Template)(nil)).Elem(),
+		"URL":                  pry.Type((*template.URL)(nil)).Elem(),
+		"URLQueryEscaper":      template.URLQueryEscaper,
+	})
+}
@@ -0,0 +1,63 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image"
+)
+
+func init() {
+	pry.RegisterPackage("image", "image", map[string]interface{}{
+		"Alpha":                  pry.Type((*image.Alpha)(nil)).Elem(),
+		"Alpha16":                pry.Type((*image.Alpha16)(nil)).Elem(),
+		"Black":                  &image.Black,
+		"CMYK":                   pry.Type((*image.CMYK)(nil)).Elem(),
+		"Config":                 pry.Type((*image.Config)(nil)).Elem(),
+		"Decode":                 image.Decode,
+		"DecodeConfig":           image.DecodeConfig,
+		"ErrFormat":              &image.ErrFormat,
+		"Gray":                   pry.Type((*image.Gray)(nil)).Elem(),
+		"Gray16":                 pry.Type((*image.Gray16)(nil)).Elem(),
+		"Image":                  pry.Type((*image.Image)(nil)).Elem(),
+		"NRGBA":                  pry.Type((*image.NRGBA)(nil)).Elem(),
+		"NRGBA64":                pry.Type((*image.NRGBA64)(nil)).Elem(),
+		"NYCbCrA":                pry.Type((*image.NYCbCrA)(nil)).Elem(),
+		"NewAlpha":               image.NewAlpha,
+		"NewAlpha16":             image.NewAlpha16,
+		"NewCMYK":                image.NewCMYK,
+		"NewGray":                image.NewGray,
+		"NewGray16":              image.NewGray16,
+		"NewNRGBA":               image.NewNRGBA,
+		"NewNRGBA64":             image.NewNRGBA64,
+		"NewNYCbCrA":             image.NewNYCbCrA,
+		"NewPaletted":            image.NewPaletted,
+		"NewRGBA":                image.NewRGBA,
+		"NewRGBA64":              image.NewRGBA64,
+		"NewUniform":             image.NewUniform,
+		"NewYCbCr":               image.NewYCbCr,
+		"Opaque":                 &image.Opaque,
+		"Paletted":               pry.Type((*image.Paletted)(nil)).Elem(),
+		"PalettedImage":          pry.Type((*image.PalettedImage)(nil)).Elem(),
+		"Point":                  pry.Type((*image.Point)(nil)).Elem(),
+		"Pt":                     image.Pt,
+		"RGBA":                   pry.Type((*image.RGBA)(nil)).Elem(),
+		"RGBA64":                 pry.Type((*image.RGBA64)(nil)).Elem(),
+		"Rect":                   image.Rect,
+		"Rectangle":              pry.Type((*image.Rectangle)(nil)).Elem(),
+		"RegisterFormat":         image.RegisterFormat,
+		"Transparent":            &image.Transparent,
+		"Uniform":                pry.Type((*image.Uniform)(nil)).Elem(),
+		"White":                  &image.White,
+		"YCbCr":                  pry.Type((*image.YCbCr)(nil)).Elem(),
+		"YCbCrSubsampleRatio":    pry.Type((*image.YCbCrSubsampleRatio)(nil)).Elem(),
+		"YCbCrSubsampleRatio410": image.YCbCrSubsampleRatio410,
+		"YCbCrSubsampleRatio411": image.YCbCrSubsampleRatio411,
+		"YCbCrSubsampleRatio420": image.YCbCrSubsampleRatio420,
+		"YCbCrSubsampleRatio422": image.YCbCrSubsampleRatio422,
+		"YCbCrSubsampleRatio440": image.YCbCrSubsampleRatio440,
+		"YCbCrSubsampleRatio444": image.YCbCrSubsampleRatio444,
+		"ZP":                     &image.ZP,
+		"ZR":                     &image.ZR,
+	})
+}
@@ -0,0 +1,47 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image/color"
+)
+
+func init() {
+	pry.RegisterPackage("image/color", "color", map[string]interface{}{
+		"Alpha":        pry.Type((*color.Alpha)(nil)).Elem(),
+		"Alpha16":      pry.Type((*color.Alpha16)(nil)).Elem(),
+		"Alpha16Model": &color.Alpha16Model,
+		"AlphaModel":   &color.AlphaModel,
+		"Black":        &color.Black,
+		"CMYK":         pry.Type((*color.CMYK)(nil)).Elem(),
+		"CMYKModel":    &color.CMYKModel,
+		"CMYKToRGB":    color.CMYKToRGB,
+		"Color":        pry.Type((*color.Color)(nil)).Elem(),
+		"Gray":         pry.Type((*color.Gray)(nil)).Elem(),
+		"Gray16":       pry.Type((*color.Gray16)(nil)).Elem(),
+		"Gray16Model":  &color.Gray16Model,
+		"GrayModel":    &color.GrayModel,
+		"Model":        pry.Type((*color.Model)(nil)).Elem(),
+		"ModelFunc":    color.ModelFunc,
+		"NRGBA":        pry.Type((*color.NRGBA)(nil)).Elem(),
+		"NRGBA64":      pry.Type((*color.NRGBA64)(nil)).Elem(),
+		"NRGBA64Model": &color.NRGBA64Model,
+		"NRGBAModel":   &color.NRGBAModel,
+		"NYCbCrA":      pry.Type((*color.NYCbCrA)(nil)).Elem(),
+		"NYCbCrAModel": &color.NYCbCrAModel,
+		"Opaque":       &color.Opaque,
+		"Palette":      pry.Type((*color.Palette)(nil)).Elem(),
+		"RGBA":         pry.Type((*color.RGBA)(nil)).Elem(),
+		"RGBA64":       pry.Type((*color.RGBA64)(nil)).Elem(),
+		"RGBA64Model":  &color.RGBA64Model,
+		"RGBAModel":    &color.RGBAModel,
+		"RGBToCMYK":    color.RGBToCMYK,
+		"RGBToYCbCr":   color.RGBToYCbCr,
+		"Transparent":  &color.Transparent,
+		"White":        &color.White,
+		"YCbCr":        pry.Type((*color.YCbCr)(nil)).Elem(),
+		"YCbCrModel":   &color.YCbCrModel,
+		"YCbCrToRGB":   color.YCbCrToRGB,
+	})
+}
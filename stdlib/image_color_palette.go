@@ -0,0 +1,15 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image/color/palette"
+)
+
+func init() {
+	pry.RegisterPackage("image/color/palette", "palette", map[string]interface{}{
+		"Plan9":   &palette.Plan9,
+		"WebSafe": &palette.WebSafe,
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image/draw"
+)
+
+func init() {
+	pry.RegisterPackage("image/draw", "draw", map[string]interface{}{
+		"Draw":           draw.Draw,
+		"DrawMask":       draw.DrawMask,
+		"Drawer":         pry.Type((*draw.Drawer)(nil)).Elem(),
+		"FloydSteinberg": &draw.FloydSteinberg,
+		"Image":          pry.Type((*draw.Image)(nil)).Elem(),
+		"Op":             pry.Type((*draw.Op)(nil)).Elem(),
+		"Over":           draw.Over,
+		"Quantizer":      pry.Type((*draw.Quantizer)(nil)).Elem(),
+		"Src":            draw.Src,
+	})
+}
@@ -0,0 +1,23 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image/gif"
+)
+
+func init() {
+	pry.RegisterPackage("image/gif", "gif", map[string]interface{}{
+		"Decode":             gif.Decode,
+		"DecodeAll":          gif.DecodeAll,
+		"DecodeConfig":       gif.DecodeConfig,
+		"DisposalBackground": gif.DisposalBackground,
+		"DisposalNone":       gif.DisposalNone,
+		"DisposalPrevious":   gif.DisposalPrevious,
+		"Encode":             gif.Encode,
+		"EncodeAll":          gif.EncodeAll,
+		"GIF":                pry.Type((*gif.GIF)(nil)).Elem(),
+		"Options":            pry.Type((*gif.Options)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,21 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image/jpeg"
+)
+
+func init() {
+	pry.RegisterPackage("image/jpeg", "jpeg", map[string]interface{}{
+		"Decode":           jpeg.Decode,
+		"DecodeConfig":     jpeg.DecodeConfig,
+		"DefaultQuality":   jpeg.DefaultQuality,
+		"Encode":           jpeg.Encode,
+		"FormatError":      pry.Type((*jpeg.FormatError)(nil)).Elem(),
+		"Options":          pry.Type((*jpeg.Options)(nil)).Elem(),
+		"Reader":           pry.Type((*jpeg.Reader)(nil)).Elem(),
+		"UnsupportedError": pry.Type((*jpeg.UnsupportedError)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,26 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"image/png"
+)
+
+func init() {
+	pry.RegisterPackage("image/png", "png", map[string]interface{}{
+		"BestCompression":    png.BestCompression,
+		"BestSpeed":          png.BestSpeed,
+		"CompressionLevel":   pry.Type((*png.CompressionLevel)(nil)).Elem(),
+		"Decode":             png.Decode,
+		"DecodeConfig":       png.DecodeConfig,
+		"DefaultCompression": png.DefaultCompression,
+		"Encode":             png.Encode,
+		"Encoder":            pry.Type((*png.Encoder)(nil)).Elem(),
+		"EncoderBuffer":      pry.Type((*png.EncoderBuffer)(nil)).Elem(),
+		"EncoderBufferPool":  pry.Type((*png.EncoderBufferPool)(nil)).Elem(),
+		"FormatError":        pry.Type((*png.FormatError)(nil)).Elem(),
+		"NoCompression":      png.NoCompression,
+		"UnsupportedError":   pry.Type((*png.UnsupportedError)(nil)).Elem(),
+	})
+}
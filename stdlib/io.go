@@ -0,0 +1,63 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"io"
+)
+
+func init() {
+	pry.RegisterPackage("io", "io", map[string]interface{}{
+		"ByteReader":       pry.Type((*io.ByteReader)(nil)).Elem(),
+		"ByteScanner":      pry.Type((*io.ByteScanner)(nil)).Elem(),
+		"ByteWriter":       pry.Type((*io.ByteWriter)(nil)).Elem(),
+		"Closer":           pry.Type((*io.Closer)(nil)).Elem(),
+		"Copy":             io.Copy,
+		"CopyBuffer":       io.CopyBuffer,
+		"CopyN":            io.CopyN,
+		"Discard":          &io.Discard,
+		"EOF":              &io.EOF,
+		"ErrClosedPipe":    &io.ErrClosedPipe,
+		"ErrNoProgress":    &io.ErrNoProgress,
+		"ErrShortBuffer":   &io.ErrShortBuffer,
+		"ErrShortWrite":    &io.ErrShortWrite,
+		"ErrUnexpectedEOF": &io.ErrUnexpectedEOF,
+		"LimitReader":      io.LimitReader,
+		"LimitedReader":    pry.Type((*io.LimitedReader)(nil)).Elem(),
+		"MultiReader":      io.MultiReader,
+		"MultiWriter":      io.MultiWriter,
+		"NewSectionReader": io.NewSectionReader,
+		"NopCloser":        io.NopCloser,
+		"Pipe":             io.Pipe,
+		"PipeReader":       pry.Type((*io.PipeReader)(nil)).Elem(),
+		"PipeWriter":       pry.Type((*io.PipeWriter)(nil)).Elem(),
+		"ReadAll":          io.ReadAll,
+		"ReadAtLeast":      io.ReadAtLeast,
+		"ReadCloser":       pry.Type((*io.ReadCloser)(nil)).Elem(),
+		"ReadFull":         io.ReadFull,
+		"ReadSeekCloser":   pry.Type((*io.ReadSeekCloser)(nil)).Elem(),
+		"ReadSeeker":       pry.Type((*io.ReadSeeker)(nil)).Elem(),
+		"ReadWriteCloser":  pry.Type((*io.ReadWriteCloser)(nil)).Elem(),
+		"ReadWriteSeeker":  pry.Type((*io.ReadWriteSeeker)(nil)).Elem(),
+		"ReadWriter":       pry.Type((*io.ReadWriter)(nil)).Elem(),
+		"Reader":           pry.Type((*io.Reader)(nil)).Elem(),
+		"ReaderAt":         pry.Type((*io.ReaderAt)(nil)).Elem(),
+		"ReaderFrom":       pry.Type((*io.ReaderFrom)(nil)).Elem(),
+		"RuneReader":       pry.Type((*io.RuneReader)(nil)).Elem(),
+		"RuneScanner":      pry.Type((*io.RuneScanner)(nil)).Elem(),
+		"SectionReader":    pry.Type((*io.SectionReader)(nil)).Elem(),
+		"SeekCurrent":      io.SeekCurrent,
+		"SeekEnd":          io.SeekEnd,
+		"SeekStart":        io.SeekStart,
+		"Seeker":           pry.Type((*io.Seeker)(nil)).Elem(),
+		"StringWriter":     pry.Type((*io.StringWriter)(nil)).Elem(),
+		"TeeReader":        io.TeeReader,
+		"WriteCloser":      pry.Type((*io.WriteCloser)(nil)).Elem(),
+		"WriteSeeker":      pry.Type((*io.WriteSeeker)(nil)).Elem(),
+		"WriteString":      io.WriteString,
+		"Writer":           pry.Type((*io.Writer)(nil)).Elem(),
+		"WriterAt":         pry.Type((*io.WriterAt)(nil)).Elem(),
+		"WriterTo":         pry.Type((*io.WriterTo)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,21 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"io/ioutil"
+)
+
+func init() {
+	pry.RegisterPackage("io/ioutil", "ioutil", map[string]interface{}{
+		"Discard":   &ioutil.Discard,
+		"NopCloser": ioutil.NopCloser,
+		"ReadAll":   ioutil.ReadAll,
+		"ReadDir":   ioutil.ReadDir,
+		"ReadFile":  ioutil.ReadFile,
+		"TempDir":   ioutil.TempDir,
+		"TempFile":  ioutil.TempFile,
+		"WriteFile": ioutil.WriteFile,
+	})
+}
@@ -0,0 +1,40 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"log"
+)
+
+func init() {
+	pry.RegisterPackage("log", "log", map[string]interface{}{
+		"Default":       log.Default,
+		"Fatal":         log.Fatal,
+		"Fatalf":        log.Fatalf,
+		"Fatalln":       log.Fatalln,
+		"Flags":         log.Flags,
+		"LUTC":          log.LUTC,
+		"Ldate":         log.Ldate,
+		"Llongfile":     log.Llongfile,
+		"Lmicroseconds": log.Lmicroseconds,
+		"Lmsgprefix":    log.Lmsgprefix,
+		"Logger":        pry.Type((*log.Logger)(nil)).Elem(),
+		"Lshortfile":    log.Lshortfile,
+		"LstdFlags":     log.LstdFlags,
+		"Ltime":         log.Ltime,
+		"New":           log.New,
+		"Output":        log.Output,
+		"Panic":         log.Panic,
+		"Panicf":        log.Panicf,
+		"Panicln":       log.Panicln,
+		"Prefix":        log.Prefix,
+		"Print":         log.Print,
+		"Printf":        log.Printf,
+		"Println":       log.Println,
+		"SetFlags":      log.SetFlags,
+		"SetOutput":     log.SetOutput,
+		"SetPrefix":     log.SetPrefix,
+		"Writer":        log.Writer,
+	})
+}
@@ -0,0 +1,107 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"math"
+)
+
+func init() {
+	pry.RegisterPackage("math", "math", map[string]interface{}{
+		"Abs":                    math.Abs,
+		"Acos":                   math.Acos,
+		"Acosh":                  math.Acosh,
+		"Asin":                   math.Asin,
+		"Asinh":                  math.Asinh,
+		"Atan":                   math.Atan,
+		"Atan2":                  math.Atan2,
+		"Atanh":                  math.Atanh,
+		"Cbrt":                   math.Cbrt,
+		"Ceil":                   math.Ceil,
+		"Copysign":               math.Copysign,
+		"Cos":                    math.Cos,
+		"Cosh":                   math.Cosh,
+		"Dim":                    math.Dim,
+		"E":                      math.E,
+		"Erf":                    math.Erf,
+		"Erfc":                   math.Erfc,
+		"Erfcinv":                math.Erfcinv,
+		"Erfinv":                 math.Erfinv,
+		"Exp":                    math.Exp,
+		"Exp2":                   math.Exp2,
+		"Expm1":                  math.Expm1,
+		"FMA":                    math.FMA,
+		"Float32bits":            math.Float32bits,
+		"Float32frombits":        math.Float32frombits,
+		"Float64bits":            math.Float64bits,
+		"Float64frombits":        math.Float64frombits,
+		"Floor":                  math.Floor,
+		"Frexp":                  math.Frexp,
+		"Gamma":                  math.Gamma,
+		"Hypot":                  math.Hypot,
+		"Ilogb":                  math.Ilogb,
+		"Inf":                    math.Inf,
+		"IsInf":                  math.IsInf,
+		"IsNaN":                  math.IsNaN,
+		"J0":                     math.J0,
+		"J1":                     math.J1,
+		"Jn":                     math.Jn,
+		"Ldexp":                  math.Ldexp,
+		"Lgamma":                 math.Lgamma,
+		"Ln10":                   math.Ln10,
+		"Ln2":                    math.Ln2,
+		"Log":                    math.Log,
+		"Log10":                  math.Log10,
+		"Log10E":                 math.Log10E,
+		"Log1p":                  math.Log1p,
+		"Log2":                   math.Log2,
+		"Log2E":                  math.Log2E,
+		"Logb":                   math.Logb,
+		"Max":                    math.Max,
+		"MaxFloat32":             math.MaxFloat32,
+		"MaxFloat64":             math.MaxFloat64,
+		"MaxInt16":               math.MaxInt16,
+		"MaxInt32":               math.MaxInt32,
+		"MaxInt64":               int64(math.MaxInt64),
+		"MaxInt8":                math.MaxInt8,
+		"MaxUint16":              math.MaxUint16,
+		"MaxUint32":              int64(math.MaxUint32),
+		"MaxUint64":              uint64(math.MaxUint64),
+		"MaxUint8":               math.MaxUint8,
+		"Min":                    math.Min,
+		"MinInt16":               math.MinInt16,
+		"MinInt32":               math.MinInt32,
+		"MinInt64":               int64(math.MinInt64),
+		"MinInt8":                math.MinInt8,
+		"Mod":                    math.Mod,
+		"Modf":                   math.Modf,
+		"NaN":                    math.NaN,
+		"Nextafter":              math.Nextafter,
+		"Nextafter32":            math.Nextafter32,
+		"Phi":                    math.Phi,
+		"Pi":                     math.Pi,
+		"Pow":                    math.Pow,
+		"Pow10":                  math.Pow10,
+		"Remainder":              math.Remainder,
+		"Round":                  math.Round,
+		"RoundToEven":            math.RoundToEven,
+		"Signbit":                math.Signbit,
+		"Sin":                    math.Sin,
+		"Sincos":                 math.Sincos,
+		"Sinh":                   math.Sinh,
+		"SmallestNonzeroFloat32": math.SmallestNonzeroFloat32,
+		"SmallestNonzeroFloat64": math.SmallestNonzeroFloat64,
+		"Sqrt":                   math.Sqrt,
+		"Sqrt2":                  math.Sqrt2,
+		"SqrtE":                  math.SqrtE,
+		"SqrtPhi":                math.SqrtPhi,
+		"SqrtPi":                 math.SqrtPi,
+		"Tan":                    math.Tan,
+		"Tanh":                   math.Tanh,
+		"Trunc":                  math.Trunc,
+		"Y0":                     math.Y0,
+		"Y1":                     math.Y1,
+		"Yn":                     math.Yn,
+	})
+}
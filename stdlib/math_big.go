@@ -0,0 +1,38 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"math/big"
+)
+
+func init() {
+	pry.RegisterPackage("math/big", "big", map[string]interface{}{
+		"Above":         big.Above,
+		"Accuracy":      pry.Type((*big.Accuracy)(nil)).Elem(),
+		"AwayFromZero":  big.AwayFromZero,
+		"Below":         big.Below,
+		"ErrNaN":        pry.Type((*big.ErrNaN)(nil)).Elem(),
+		"Exact":         big.Exact,
+		"Float":         pry.Type((*big.Float)(nil)).Elem(),
+		"Int":           pry.Type((*big.Int)(nil)).Elem(),
+		"Jacobi":        big.Jacobi,
+		"MaxBase":       big.MaxBase,
+		"MaxExp":        big.MaxExp,
+		"MaxPrec":       int64(big.MaxPrec),
+		"MinExp":        big.MinExp,
+		"NewFloat":      big.NewFloat,
+		"NewInt":        big.NewInt,
+		"NewRat":        big.NewRat,
+		"ParseFloat":    big.ParseFloat,
+		"Rat":           pry.Type((*big.Rat)(nil)).Elem(),
+		"RoundingMode":  pry.Type((*big.RoundingMode)(nil)).Elem(),
+		"ToNearestAway": big.ToNearestAway,
+		"ToNearestEven": big.ToNearestEven,
+		"ToNegativeInf": big.ToNegativeInf,
+		"ToPositiveInf": big.ToPositiveInf,
+		"ToZero":        big.ToZero,
+		"Word":          pry.Type((*big.Word)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,36 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"math/rand"
+)
+
+func init() {
+	pry.RegisterPackage("math/rand", "rand", map[string]interface{}{
+		"ExpFloat64":  rand.ExpFloat64,
+		"Float32":     rand.Float32,
+		"Float64":     rand.Float64,
+		"Int":         rand.Int,
+		"Int31":       rand.Int31,
+		"Int31n":      rand.Int31n,
+		"Int63":       rand.Int63,
+		"Int63n":      rand.Int63n,
+		"Intn":        rand.Intn,
+		"New":         rand.New,
+		"NewSource":   rand.NewSource,
+		"NewZipf":     rand.NewZipf,
+		"NormFloat64": rand.NormFloat64,
+		"Perm":        rand.Perm,
+		"Rand":        pry.Type((*rand.Rand)(nil)).Elem(),
+		"Read":        rand.Read,
+		"Seed":        rand.Seed,
+		"Shuffle":     rand.Shuffle,
+		"Source":      pry.Type((*rand.Source)(nil)).Elem(),
+		"Source64":    pry.Type((*rand.Source64)(nil)).Elem(),
+		"Uint32":      rand.Uint32,
+		"Uint64":      rand.Uint64,
+		"Zipf":        pry.Type((*rand.Zipf)(nil)).Elem(),
+	})
+}
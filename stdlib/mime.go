@@ -0,0 +1,23 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"mime"
+)
+
+func init() {
+	pry.RegisterPackage("mime", "mime", map[string]interface{}{
+		"AddExtensionType":         mime.AddExtensionType,
+		"BEncoding":                mime.BEncoding,
+		"ErrInvalidMediaParameter": &mime.ErrInvalidMediaParameter,
+		"ExtensionsByType":         mime.ExtensionsByType,
+		"FormatMediaType":          mime.FormatMediaType,
+		"ParseMediaType":           mime.ParseMediaType,
+		"QEncoding":                mime.QEncoding,
+		"TypeByExtension":          mime.TypeByExtension,
+		"WordDecoder":              pry.Type((*mime.WordDecoder)(nil)).Elem(),
+		"WordEncoder":              pry.Type((*mime.WordEncoder)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"mime/multipart"
+)
+
+func init() {
+	pry.RegisterPackage("mime/multipart", "multipart", map[string]interface{}{
+		"ErrMessageTooLarge": &multipart.ErrMessageTooLarge,
+		"File":               pry.Type((*multipart.File)(nil)).Elem(),
+		"FileHeader":         pry.Type((*multipart.FileHeader)(nil)).Elem(),
+		"Form":               pry.Type((*multipart.Form)(nil)).Elem(),
+		"NewReader":          multipart.NewReader,
+		"NewWriter":          multipart.NewWriter,
+		"Part":               pry.Type((*multipart.Part)(nil)).Elem(),
+		"Reader":             pry.Type((*multipart.Reader)(nil)).Elem(),
+		"Writer":             pry.Type((*multipart.Writer)(nil)).Elem(),
+	})
+}
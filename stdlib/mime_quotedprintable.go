@@ -0,0 +1,17 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"mime/quotedprintable"
+)
+
+func init() {
+	pry.RegisterPackage("mime/quotedprintable", "quotedprintable", map[string]interface{}{
+		"NewReader": quotedprintable.NewReader,
+		"NewWriter": quotedprintable.NewWriter,
+		"Reader":    pry.Type((*quotedprintable.Reader)(nil)).Elem(),
+		"Writer":    pry.Type((*quotedprintable.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,111 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net"
+)
+
+func init() {
+	pry.RegisterPackage("net", "net", map[string]interface{}{
+		"Addr":                       pry.Type((*net.Addr)(nil)).Elem(),
+		"AddrError":                  pry.Type((*net.AddrError)(nil)).Elem(),
+		"Buffers":                    pry.Type((*net.Buffers)(nil)).Elem(),
+		"CIDRMask":                   net.CIDRMask,
+		"Conn":                       pry.Type((*net.Conn)(nil)).Elem(),
+		"DNSConfigError":             pry.Type((*net.DNSConfigError)(nil)).Elem(),
+		"DNSError":                   pry.Type((*net.DNSError)(nil)).Elem(),
+		"DefaultResolver":            &net.DefaultResolver,
+		"Dial":                       net.Dial,
+		"DialIP":                     net.DialIP,
+		"DialTCP":                    net.DialTCP,
+		"DialTimeout":                net.DialTimeout,
+		"DialUDP":                    net.DialUDP,
+		"DialUnix":                   net.DialUnix,
+		"Dialer":                     pry.Type((*net.Dialer)(nil)).Elem(),
+		"ErrClosed":                  &net.ErrClosed,
+		"ErrWriteToConnected":        &net.ErrWriteToConnected,
+		"Error":                      pry.Type((*net.Error)(nil)).Elem(),
+		"FileConn":                   net.FileConn,
+		"FileListener":               net.FileListener,
+		"FilePacketConn":             net.FilePacketConn,
+		"FlagBroadcast":              net.FlagBroadcast,
+		"FlagLoopback":               net.FlagLoopback,
+		"FlagMulticast":              net.FlagMulticast,
+		"FlagPointToPoint":           net.FlagPointToPoint,
+		"FlagUp":                     net.FlagUp,
+		"Flags":                      pry.Type((*net.Flags)(nil)).Elem(),
+		"HardwareAddr":               pry.Type((*net.HardwareAddr)(nil)).Elem(),
+		"IP":                         pry.Type((*net.IP)(nil)).Elem(),
+		"IPAddr":                     pry.Type((*net.IPAddr)(nil)).Elem(),
+		"IPConn":                     pry.Type((*net.IPConn)(nil)).Elem(),
+		"IPMask":                     pry.Type((*net.IPMask)(nil)).Elem(),
+		"IPNet":                      pry.Type((*net.IPNet)(nil)).Elem(),
+		"IPv4":                       net.IPv4,
+		"IPv4Mask":                   net.IPv4Mask,
+		"IPv4allrouter":              &net.IPv4allrouter,
+		"IPv4allsys":                 &net.IPv4allsys,
+		"IPv4bcast":                  &net.IPv4bcast,
+		"IPv4len":                    net.IPv4len,
+		"IPv4zero":                   &net.IPv4zero,
+		"IPv6interfacelocalallnodes": &net.IPv6interfacelocalallnodes,
+		"IPv6len":                    net.IPv6len,
+		"IPv6linklocalallnodes":      &net.IPv6linklocalallnodes,
+		"IPv6linklocalallrouters":    &net.IPv6linklocalallrouters,
+		"IPv6loopback":               &net.IPv6loopback,
+		"IPv6unspecified":            &net.IPv6unspecified,
+		"IPv6zero":                   &net.IPv6zero,
+		"Interface":                  pry.Type((*net.Interface)(nil)).Elem(),
+		"InterfaceAddrs":             net.InterfaceAddrs,
+		"InterfaceByIndex":           net.InterfaceByIndex,
+		"InterfaceByName":            net.InterfaceByName,
+		"Interfaces":                 net.Interfaces,
+		"InvalidAddrError":           pry.Type((*net.InvalidAddrError)(nil)).Elem(),
+		"JoinHostPort":               net.JoinHostPort,
+		"Listen":                     net.Listen,
+		"ListenConfig":               pry.Type((*net.ListenConfig)(nil)).Elem(),
+		"ListenIP":                   net.ListenIP,
+		"ListenMulticastUDP":         net.ListenMulticastUDP,
+		"ListenPacket":               net.ListenPacket,
+		"ListenTCP":                  net.ListenTCP,
+		"ListenUDP":                  net.ListenUDP,
+		"ListenUnix":                 net.ListenUnix,
+		"ListenUnixgram":             net.ListenUnixgram,
+		"Listener":                   pry.Type((*net.Listener)(nil)).Elem(),
+		"LookupAddr":                 net.LookupAddr,
+		"LookupCNAME":                net.LookupCNAME,
+		"LookupHost":                 net.LookupHost,
+		"LookupIP":                   net.LookupIP,
+		"LookupMX":                   net.LookupMX,
+		"LookupNS":                   net.LookupNS,
+		"LookupPort":                 net.LookupPort,
+		"LookupSRV":                  net.LookupSRV,
+		"LookupTXT":                  net.LookupTXT,
+		"MX":                         pry.Type((*net.MX)(nil)).Elem(),
+		"NS":                         pry.Type((*net.NS)(nil)).Elem(),
+		"OpError":                    pry.Type((*net.OpError)(nil)).Elem(),
+		"PacketConn":                 pry.Type((*net.PacketConn)(nil)).Elem(),
+		"ParseCIDR":                  net.ParseCIDR,
+		"ParseError":                 pry.Type((*net.ParseError)(nil)).Elem(),
+		"ParseIP":                    net.ParseIP,
+		"ParseMAC":                   net.ParseMAC,
+		"Pipe":                       net.Pipe,
+		"ResolveIPAddr":              net.ResolveIPAddr,
+		"ResolveTCPAddr":             net.ResolveTCPAddr,
+		"ResolveUDPAddr":             net.ResolveUDPAddr,
+		"ResolveUnixAddr":            net.ResolveUnixAddr,
+		"Resolver":                   pry.Type((*net.Resolver)(nil)).Elem(),
+		"SRV":                        pry.Type((*net.SRV)(nil)).Elem(),
+		"SplitHostPort":              net.SplitHostPort,
+		"TCPAddr":                    pry.Type((*net.TCPAddr)(nil)).Elem(),
+		"TCPConn":                    pry.Type((*net.TCPConn)(nil)).Elem(),
+		"TCPListener":                pry.Type((*net.TCPListener)(nil)).Elem(),
+		"UDPAddr":                    pry.Type((*net.UDPAddr)(nil)).Elem(),
+		"UDPConn":                    pry.Type((*net.UDPConn)(nil)).Elem(),
+		"UnixAddr":                   pry.Type((*net.UnixAddr)(nil)).Elem(),
+		"UnixConn":                   pry.Type((*net.UnixConn)(nil)).Elem(),
+		"UnixListener":               pry.Type((*net.UnixListener)(nil)).Elem(),
+		"UnknownNetworkError":        pry.Type((*net.UnknownNetworkError)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,184 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/http"
+)
+
+func init() {
+	pry.RegisterPackage("net/http", "http", map[string]interface{}{
+		"CanonicalHeaderKey":                  http.CanonicalHeaderKey,
+		"Client":                              pry.Type((*http.Client)(nil)).Elem(),
+		"CloseNotifier":                       pry.Type((*http.CloseNotifier)(nil)).Elem(),
+		"ConnState":                           pry.Type((*http.ConnState)(nil)).Elem(),
+		"Cookie":                              pry.Type((*http.Cookie)(nil)).Elem(),
+		"CookieJar":                           pry.Type((*http.CookieJar)(nil)).Elem(),
+		"DefaultClient":                       &http.DefaultClient,
+		"DefaultMaxHeaderBytes":               http.DefaultMaxHeaderBytes,
+		"DefaultMaxIdleConnsPerHost":          http.DefaultMaxIdleConnsPerHost,
+		"DefaultServeMux":                     &http.DefaultServeMux,
+		"DefaultTransport":                    &http.DefaultTransport,
+		"DetectContentType":                   http.DetectContentType,
+		"Dir":                                 pry.Type((*http.Dir)(nil)).Elem(),
+		"ErrAbortHandler":                     &http.ErrAbortHandler,
+		"ErrBodyNotAllowed":                   &http.ErrBodyNotAllowed,
+		"ErrBodyReadAfterClose":               &http.ErrBodyReadAfterClose,
+		"ErrContentLength":                    &http.ErrContentLength,
+		"ErrHandlerTimeout":                   &http.ErrHandlerTimeout,
+		"ErrHeaderTooLong":                    &http.ErrHeaderTooLong,
+		"ErrHijacked":                         &http.ErrHijacked,
+		"ErrLineTooLong":                      &http.ErrLineTooLong,
+		"ErrMissingBoundary":                  &http.ErrMissingBoundary,
+		"ErrMissingContentLength":             &http.ErrMissingContentLength,
+		"ErrMissingFile":                      &http.ErrMissingFile,
+		"ErrNoCookie":                         &http.ErrNoCookie,
+		"ErrNoLocation":                       &http.ErrNoLocation,
+		"ErrNotMultipart":                     &http.ErrNotMultipart,
+		"ErrNotSupported":                     &http.ErrNotSupported,
+		"ErrServerClosed":                     &http.ErrServerClosed,
+		"ErrShortBody":                        &http.ErrShortBody,
+		"ErrSkipAltProtocol":                  &http.ErrSkipAltProtocol,
+		"ErrUnexpectedTrailer":                &http.ErrUnexpectedTrailer,
+		"ErrUseLastResponse":                  &http.ErrUseLastResponse,
+		"ErrWriteAfterFlush":                  &http.ErrWriteAfterFlush,
+		"Error":                               http.Error,
+		"FS":                                  http.FS,
+		"File":                                pry.Type((*http.File)(nil)).Elem(),
+		"FileServer":                          http.FileServer,
+		"FileSystem":                          pry.Type((*http.FileSystem)(nil)).Elem(),
+		"Flusher":                             pry.Type((*http.Flusher)(nil)).Elem(),
+		"Get":                                 http.Get,
+		"Handle":                              http.Handle,
+		"HandleFunc":                          http.HandleFunc,
+		"Handler":                             pry.Type((*http.Handler)(nil)).Elem(),
+		"HandlerFunc":                         pry.Type((*http.HandlerFunc)(nil)).Elem(),
+		"Head":                                http.Head,
+		"Header":                              pry.Type((*http.Header)(nil)).Elem(),
+		"Hijacker":                            pry.Type((*http.Hijacker)(nil)).Elem(),
+		"ListenAndServe":                      http.ListenAndServe,
+		"ListenAndServeTLS":                   http.ListenAndServeTLS,
+		"LocalAddrContextKey":                 &http.LocalAddrContextKey,
+		"MaxBytesReader":                      http.MaxBytesReader,
+		"MethodConnect":                       http.MethodConnect,
+		"MethodDelete":                        http.MethodDelete,
+		"MethodGet":                           http.MethodGet,
+		"MethodHead":                          http.MethodHead,
+		"MethodOptions":                       http.MethodOptions,
+		"MethodPatch":                         http.MethodPatch,
+		"MethodPost":                          http.MethodPost,
+		"MethodPut":                           http.MethodPut,
+		"MethodTrace":                         http.MethodTrace,
+		"NewFileTransport":                    http.NewFileTransport,
+		"NewRequest":                          http.NewRequest,
+		"NewRequestWithContext":               http.NewRequestWithContext,
+		"NewServeMux":                         http.NewServeMux,
+		"NoBody":                              &http.NoBody,
+		"NotFound":                            http.NotFound,
+		"NotFoundHandler":                     http.NotFoundHandler,
+		"ParseHTTPVersion":                    http.ParseHTTPVersion,
+		"ParseTime":                           http.ParseTime,
+		"Post":                                http.Post,
+		"PostForm":                            http.PostForm,
+		"ProtocolError":                       pry.Type((*http.ProtocolError)(nil)).Elem(),
+		"ProxyFromEnvironment":                http.ProxyFromEnvironment,
+		"ProxyURL":                            http.ProxyURL,
+		"PushOptions":                         pry.Type((*http.PushOptions)(nil)).Elem(),
+		"Pusher":                              pry.Type((*http.Pusher)(nil)).Elem(),
+		"ReadRequest":                         http.ReadRequest,
+		"ReadResponse":                        http.ReadResponse,
+		"Redirect":                            http.Redirect,
+		"RedirectHandler":                     http.RedirectHandler,
+		"Request":                             pry.Type((*http.Request)(nil)).Elem(),
+		"Response":                            pry.Type((*http.Response)(nil)).Elem(),
+		"ResponseWriter":                      pry.Type((*http.ResponseWriter)(nil)).Elem(),
+		"RoundTripper":                        pry.Type((*http.RoundTripper)(nil)).Elem(),
+		"SameSite":                            pry.Type((*http.SameSite)(nil)).Elem(),
+		"SameSiteDefaultMode":                 http.SameSiteDefaultMode,
+		"SameSiteLaxMode":                     http.SameSiteLaxMode,
+		"SameSiteNoneMode":                    http.SameSiteNoneMode,
+		"SameSiteStrictMode":                  http.SameSiteStrictMode,
+		"Serve":                               http.Serve,
+		"ServeContent":                        http.ServeContent,
+		"ServeFile":                           http.ServeFile,
+		"ServeMux":                            pry.Type((*http.ServeMux)(nil)).Elem(),
+		"ServeTLS":                            http.ServeTLS,
+		"Server":                              pry.Type((*http.Server)(nil)).Elem(),
+		"ServerContextKey":                    &http.ServerContextKey,
+		"SetCookie":                           http.SetCookie,
+		"StateActive":                         http.StateActive,
+		"StateClosed":                         http.StateClosed,
+		"StateHijacked":                       http.StateHijacked,
+		"StateIdle":                           http.StateIdle,
+		"StateNew":                            http.StateNew,
+		"StatusAccepted":                      http.StatusAccepted,
+		"StatusAlreadyReported":               http.StatusAlreadyReported,
+		"StatusBadGateway":                    http.StatusBadGateway,
+		"StatusBadRequest":                    http.StatusBadRequest,
+		"StatusConflict":                      http.StatusConflict,
+		"StatusContinue":                      http.StatusContinue,
+		"StatusCreated":                       http.StatusCreated,
+		"StatusEarlyHints":                    http.StatusEarlyHints,
+		"StatusExpectationFailed":             http.StatusExpectationFailed,
+		"StatusFailedDependency":              http.StatusFailedDependency,
+		"StatusForbidden":                     http.StatusForbidden,
+		"StatusFound":                         http.StatusFound,
+		"StatusGatewayTimeout":                http.StatusGatewayTimeout,
+		"StatusGone":                          http.StatusGone,
+		"StatusHTTPVersionNotSupported":       http.StatusHTTPVersionNotSupported,
+		"StatusIMUsed":                        http.StatusIMUsed,
+		"StatusInsufficientStorage":           http.StatusInsufficientStorage,
+		"StatusInternalServerError":           http.StatusInternalServerError,
+		"StatusLengthRequired":                http.StatusLengthRequired,
+		"StatusLocked":                        http.StatusLocked,
+		"StatusLoopDetected":                  http.StatusLoopDetected,
+		"StatusMethodNotAllowed":              http.StatusMethodNotAllowed,
+		"StatusMisdirectedRequest":            http.StatusMisdirectedRequest,
+		"StatusMovedPermanently":              http.StatusMovedPermanently,
+		"StatusMultiStatus":                   http.StatusMultiStatus,
+		"StatusMultipleChoices":               http.StatusMultipleChoices,
+		"StatusNetworkAuthenticationRequired": http.StatusNetworkAuthenticationRequired,
+		"StatusNoContent":                     http.StatusNoContent,
+		"StatusNonAuthoritativeInfo":          http.StatusNonAuthoritativeInfo,
+		"StatusNotAcceptable":                 http.StatusNotAcceptable,
+		"StatusNotExtended":                   http.StatusNotExtended,
+		"StatusNotFound":                      http.StatusNotFound,
+		"StatusNotImplemented":                http.StatusNotImplemented,
+		"StatusNotModified":                   http.StatusNotModified,
+		"StatusOK":                            http.StatusOK,
+		"StatusPartialContent":                http.StatusPartialContent,
+		"StatusPaymentRequired":               http.StatusPaymentRequired,
+		"StatusPermanentRedirect":             http.StatusPermanentRedirect,
+		"StatusPreconditionFailed":            http.StatusPreconditionFailed,
+		"StatusPreconditionRequired":          http.StatusPreconditionRequired,
+		"StatusProcessing":                    http.StatusProcessing,
+		"StatusProxyAuthRequired":             http.StatusProxyAuthRequired,
+		"StatusRequestEntityTooLarge":         http.StatusRequestEntityTooLarge,
+		"StatusRequestHeaderFieldsTooLarge":   http.StatusRequestHeaderFieldsTooLarge,
+		"StatusRequestTimeout":                http.StatusRequestTimeout,
+		"StatusRequestURITooLong":             http.StatusRequestURITooLong,
+		"StatusRequestedRangeNotSatisfiable":  http.StatusRequestedRangeNotSatisfiable,
+		"StatusResetContent":                  http.StatusResetContent,
+		"StatusSeeOther":                      http.StatusSeeOther,
+		"StatusServiceUnavailable":            http.StatusServiceUnavailable,
+		"StatusSwitchingProtocols":            http.StatusSwitchingProtocols,
+		"StatusTeapot":                        http.StatusTeapot,
+		"StatusTemporaryRedirect":             http.StatusTemporaryRedirect,
+		"StatusText":                          http.StatusText,
+		"StatusTooEarly":                      http.StatusTooEarly,
+		"StatusTooManyRequests":               http.StatusTooManyRequests,
+		"StatusUnauthorized":                  http.StatusUnauthorized,
+		"StatusUnavailableForLegalReasons":    http.StatusUnavailableForLegalReasons,
+		"StatusUnprocessableEntity":           http.StatusUnprocessableEntity,
+		"StatusUnsupportedMediaType":          http.StatusUnsupportedMediaType,
+		"StatusUpgradeRequired":               http.StatusUpgradeRequired,
+		"StatusUseProxy":                      http.StatusUseProxy,
+		"StatusVariantAlsoNegotiates":         http.StatusVariantAlsoNegotiates,
+		"StripPrefix":                         http.StripPrefix,
+		"TimeFormat":                          http.TimeFormat,
+		"TimeoutHandler":                      http.TimeoutHandler,
+		"TrailerPrefix":                       http.TrailerPrefix,
+		"Transport":                           pry.Type((*http.Transport)(nil)).Elem(),
+	})
+}
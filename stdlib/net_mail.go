@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/mail"
+)
+
+func init() {
+	pry.RegisterPackage("net/mail", "mail", map[string]interface{}{
+		"Address":             pry.Type((*mail.Address)(nil)).Elem(),
+		"AddressParser":       pry.Type((*mail.AddressParser)(nil)).Elem(),
+		"ErrHeaderNotPresent": &mail.ErrHeaderNotPresent,
+		"Header":              pry.Type((*mail.Header)(nil)).Elem(),
+		"Message":             pry.Type((*mail.Message)(nil)).Elem(),
+		"ParseAddress":        mail.ParseAddress,
+		"ParseAddressList":    mail.ParseAddressList,
+		"ParseDate":           mail.ParseDate,
+		"ReadMessage":         mail.ReadMessage,
+	})
+}
@@ -0,0 +1,38 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/rpc"
+)
+
+func init() {
+	pry.RegisterPackage("net/rpc", "rpc", map[string]interface{}{
+		"Accept":             rpc.Accept,
+		"Call":               pry.Type((*rpc.Call)(nil)).Elem(),
+		"Client":             pry.Type((*rpc.Client)(nil)).Elem(),
+		"ClientCodec":        pry.Type((*rpc.ClientCodec)(nil)).Elem(),
+		"DefaultDebugPath":   rpc.DefaultDebugPath,
+		"DefaultRPCPath":     rpc.DefaultRPCPath,
+		"DefaultServer":      &rpc.DefaultServer,
+		"Dial":               rpc.Dial,
+		"DialHTTP":           rpc.DialHTTP,
+		"DialHTTPPath":       rpc.DialHTTPPath,
+		"ErrShutdown":        &rpc.ErrShutdown,
+		"HandleHTTP":         rpc.HandleHTTP,
+		"NewClient":          rpc.NewClient,
+		"NewClientWithCodec": rpc.NewClientWithCodec,
+		"NewServer":          rpc.NewServer,
+		"Register":           rpc.Register,
+		"RegisterName":       rpc.RegisterName,
+		"Request":            pry.Type((*rpc.Request)(nil)).Elem(),
+		"Response":           pry.Type((*rpc.Response)(nil)).Elem(),
+		"ServeCodec":         rpc.ServeCodec,
+		"ServeConn":          rpc.ServeConn,
+		"ServeRequest":       rpc.ServeRequest,
+		"Server":             pry.Type((*rpc.Server)(nil)).Elem(),
+		"ServerCodec":        pry.Type((*rpc.ServerCodec)(nil)).Elem(),
+		"ServerError":        pry.Type((*rpc.ServerError)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,18 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/rpc/jsonrpc"
+)
+
+func init() {
+	pry.RegisterPackage("net/rpc/jsonrpc", "jsonrpc", map[string]interface{}{
+		"Dial":           jsonrpc.Dial,
+		"NewClient":      jsonrpc.NewClient,
+		"NewClientCodec": jsonrpc.NewClientCodec,
+		"NewServerCodec": jsonrpc.NewServerCodec,
+		"ServeConn":      jsonrpc.ServeConn,
+	})
+}
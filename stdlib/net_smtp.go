@@ -0,0 +1,21 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/smtp"
+)
+
+func init() {
+	pry.RegisterPackage("net/smtp", "smtp", map[string]interface{}{
+		"Auth":        pry.Type((*smtp.Auth)(nil)).Elem(),
+		"CRAMMD5Auth": smtp.CRAMMD5Auth,
+		"Client":      pry.Type((*smtp.Client)(nil)).Elem(),
+		"Dial":        smtp.Dial,
+		"NewClient":   smtp.NewClient,
+		"PlainAuth":   smtp.PlainAuth,
+		"SendMail":    smtp.SendMail,
+		"ServerInfo":  pry.Type((*smtp.ServerInfo)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,27 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/textproto"
+)
+
+func init() {
+	pry.RegisterPackage("net/textproto", "textproto", map[string]interface{}{
+		"CanonicalMIMEHeaderKey": textproto.CanonicalMIMEHeaderKey,
+		"Conn":                   pry.Type((*textproto.Conn)(nil)).Elem(),
+		"Dial":                   textproto.Dial,
+		"Error":                  pry.Type((*textproto.Error)(nil)).Elem(),
+		"MIMEHeader":             pry.Type((*textproto.MIMEHeader)(nil)).Elem(),
+		"NewConn":                textproto.NewConn,
+		"NewReader":              textproto.NewReader,
+		"NewWriter":              textproto.NewWriter,
+		"Pipeline":               pry.Type((*textproto.Pipeline)(nil)).Elem(),
+		"ProtocolError":          pry.Type((*textproto.ProtocolError)(nil)).Elem(),
+		"Reader":                 pry.Type((*textproto.Reader)(nil)).Elem(),
+		"TrimBytes":              textproto.TrimBytes,
+		"TrimString":             textproto.TrimString,
+		"Writer":                 pry.Type((*textproto.Writer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,28 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"net/url"
+)
+
+func init() {
+	pry.RegisterPackage("net/url", "url", map[string]interface{}{
+		"Error":            pry.Type((*url.Error)(nil)).Elem(),
+		"EscapeError":      pry.Type((*url.EscapeError)(nil)).Elem(),
+		"InvalidHostError": pry.Type((*url.InvalidHostError)(nil)).Elem(),
+		"Parse":            url.Parse,
+		"ParseQuery":       url.ParseQuery,
+		"ParseRequestURI":  url.ParseRequestURI,
+		"PathEscape":       url.PathEscape,
+		"PathUnescape":     url.PathUnescape,
+		"QueryEscape":      url.QueryEscape,
+		"QueryUnescape":    url.QueryUnescape,
+		"URL":              pry.Type((*url.URL)(nil)).Elem(),
+		"User":             url.User,
+		"UserPassword":     url.UserPassword,
+		"Userinfo":         pry.Type((*url.Userinfo)(nil)).Elem(),
+		"Values":           pry.Type((*url.Values)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,20 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"os/exec"
+)
+
+func init() {
+	pry.RegisterPackage("os/exec", "exec", map[string]interface{}{
+		"Cmd":            pry.Type((*exec.Cmd)(nil)).Elem(),
+		"Command":        exec.Command,
+		"CommandContext": exec.CommandContext,
+		"ErrNotFound":    &exec.ErrNotFound,
+		"Error":          pry.Type((*exec.Error)(nil)).Elem(),
+		"ExitError":      pry.Type((*exec.ExitError)(nil)).Elem(),
+		"LookPath":       exec.LookPath,
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"path"
+)
+
+func init() {
+	pry.RegisterPackage("path", "path", map[string]interface{}{
+		"Base":          path.Base,
+		"Clean":         path.Clean,
+		"Dir":           path.Dir,
+		"ErrBadPattern": &path.ErrBadPattern,
+		"Ext":           path.Ext,
+		"IsAbs":         path.IsAbs,
+		"Join":          path.Join,
+		"Match":         path.Match,
+		"Split":         path.Split,
+	})
+}
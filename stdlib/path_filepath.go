@@ -0,0 +1,37 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"path/filepath"
+)
+
+func init() {
+	pry.RegisterPackage("path/filepath", "filepath", map[string]interface{}{
+		"Abs":           filepath.Abs,
+		"Base":          filepath.Base,
+		"Clean":         filepath.Clean,
+		"Dir":           filepath.Dir,
+		"ErrBadPattern": &filepath.ErrBadPattern,
+		"EvalSymlinks":  filepath.EvalSymlinks,
+		"Ext":           filepath.Ext,
+		"FromSlash":     filepath.FromSlash,
+		"Glob":          filepath.Glob,
+		"HasPrefix":     filepath.HasPrefix,
+		"IsAbs":         filepath.IsAbs,
+		"Join":          filepath.Join,
+		"ListSeparator": filepath.ListSeparator,
+		"Match":         filepath.Match,
+		"Rel":           filepath.Rel,
+		"Separator":     filepath.Separator,
+		"SkipDir":       &filepath.SkipDir,
+		"Split":         filepath.Split,
+		"SplitList":     filepath.SplitList,
+		"ToSlash":       filepath.ToSlash,
+		"VolumeName":    filepath.VolumeName,
+		"Walk":          filepath.Walk,
+		"WalkDir":       filepath.WalkDir,
+		"WalkFunc":      pry.Type((*filepath.WalkFunc)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,16 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"plugin"
+)
+
+func init() {
+	pry.RegisterPackage("plugin", "plugin", map[string]interface{}{
+		"Open":   plugin.Open,
+		"Plugin": pry.Type((*plugin.Plugin)(nil)).Elem(),
+		"Symbol": pry.Type((*plugin.Symbol)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,83 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"reflect"
+)
+
+func init() {
+	pry.RegisterPackage("reflect", "reflect", map[string]interface{}{
+		"Append":          reflect.Append,
+		"AppendSlice":     reflect.AppendSlice,
+		"Array":           reflect.Array,
+		"ArrayOf":         reflect.ArrayOf,
+		"Bool":            reflect.Bool,
+		"BothDir":         reflect.BothDir,
+		"Chan":            reflect.Chan,
+		"ChanDir":         pry.Type((*reflect.ChanDir)(nil)).Elem(),
+		"ChanOf":          reflect.ChanOf,
+		"Complex128":      reflect.Complex128,
+		"Complex64":       reflect.Complex64,
+		"Copy":            reflect.Copy,
+		"DeepEqual":       reflect.DeepEqual,
+		"Float32":         reflect.Float32,
+		"Float64":         reflect.Float64,
+		"Func":            reflect.Func,
+		"FuncOf":          reflect.FuncOf,
+		"Indirect":        reflect.Indirect,
+		"Int":             reflect.Int,
+		"Int16":           reflect.Int16,
+		"Int32":           reflect.Int32,
+		"Int64":           reflect.Int64,
+		"Int8":            reflect.Int8,
+		"Interface":       reflect.Interface,
+		"Invalid":         reflect.Invalid,
+		"Kind":            pry.Type((*reflect.Kind)(nil)).Elem(),
+		"MakeChan":        reflect.MakeChan,
+		"MakeFunc":        reflect.MakeFunc,
+		"MakeMap":         reflect.MakeMap,
+		"MakeMapWithSize": reflect.MakeMapWithSize,
+		"MakeSlice":       reflect.MakeSlice,
+		"Map":             reflect.Map,
+		"MapIter":         pry.Type((*reflect.MapIter)(nil)).Elem(),
+		"MapOf":           reflect.MapOf,
+		"Method":          pry.Type((*reflect.Method)(nil)).Elem(),
+		"New":             reflect.New,
+		"NewAt":           reflect.NewAt,
+		"Ptr":             reflect.Ptr,
+		"PtrTo":           reflect.PtrTo,
+		"RecvDir":         reflect.RecvDir,
+		"Select":          reflect.Select,
+		"SelectCase":      pry.Type((*reflect.SelectCase)(nil)).Elem(),
+		"SelectDefault":   reflect.SelectDefault,
+		"SelectDir":       pry.Type((*reflect.SelectDir)(nil)).Elem(),
+		"SelectRecv":      reflect.SelectRecv,
+		"SelectSend":      reflect.SelectSend,
+		"SendDir":         reflect.SendDir,
+		"Slice":           reflect.Slice,
+		"SliceHeader":     pry.Type((*reflect.SliceHeader)(nil)).Elem(),
+		"SliceOf":         reflect.SliceOf,
+		"String":          reflect.String,
+		"StringHeader":    pry.Type((*reflect.StringHeader)(nil)).Elem(),
+		"Struct":          reflect.Struct,
+		"StructField":     pry.Type((*reflect.StructField)(nil)).Elem(),
+		"StructOf":        reflect.StructOf,
+		"StructTag":       pry.Type((*reflect.StructTag)(nil)).Elem(),
+		"Swapper":         reflect.Swapper,
+		"Type":            pry.Type((*reflect.Type)(nil)).Elem(),
+		"TypeOf":          reflect.TypeOf,
+		"Uint":            reflect.Uint,
+		"Uint16":          reflect.Uint16,
+		"Uint32":          reflect.Uint32,
+		"Uint64":          reflect.Uint64,
+		"Uint8":           reflect.Uint8,
+		"Uintptr":         reflect.Uintptr,
+		"UnsafePointer":   reflect.UnsafePointer,
+		"Value":           pry.Type((*reflect.Value)(nil)).Elem(),
+		"ValueError":      pry.Type((*reflect.ValueError)(nil)).Elem(),
+		"ValueOf":         reflect.ValueOf,
+		"Zero":            reflect.Zero,
+	})
+}
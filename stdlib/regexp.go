@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"regexp"
+)
+
+func init() {
+	pry.RegisterPackage("regexp", "regexp", map[string]interface{}{
+		"Compile":          regexp.Compile,
+		"CompilePOSIX":     regexp.CompilePOSIX,
+		"Match":            regexp.Match,
+		"MatchReader":      regexp.MatchReader,
+		"MatchString":      regexp.MatchString,
+		"MustCompile":      regexp.MustCompile,
+		"MustCompilePOSIX": regexp.MustCompilePOSIX,
+		"QuoteMeta":        regexp.QuoteMeta,
+		"Regexp":           pry.Type((*regexp.Regexp)(nil)).Elem(),
+	})
+}
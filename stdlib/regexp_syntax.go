@@ -0,0 +1,89 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"regexp/syntax"
+)
+
+func init() {
+	pry.RegisterPackage("regexp/syntax", "syntax", map[string]interface{}{
+		"ClassNL":                  syntax.ClassNL,
+		"Compile":                  syntax.Compile,
+		"DotNL":                    syntax.DotNL,
+		"EmptyBeginLine":           syntax.EmptyBeginLine,
+		"EmptyBeginText":           syntax.EmptyBeginText,
+		"EmptyEndLine":             syntax.EmptyEndLine,
+		"EmptyEndText":             syntax.EmptyEndText,
+		"EmptyNoWordBoundary":      syntax.EmptyNoWordBoundary,
+		"EmptyOp":                  pry.Type((*syntax.EmptyOp)(nil)).Elem(),
+		"EmptyOpContext":           syntax.EmptyOpContext,
+		"EmptyWordBoundary":        syntax.EmptyWordBoundary,
+		"ErrInternalError":         syntax.ErrInternalError,
+		"ErrInvalidCharClass":      syntax.ErrInvalidCharClass,
+		"ErrInvalidCharRange":      syntax.ErrInvalidCharRange,
+		"ErrInvalidEscape":         syntax.ErrInvalidEscape,
+		"ErrInvalidNamedCapture":   syntax.ErrInvalidNamedCapture,
+		"ErrInvalidPerlOp":         syntax.ErrInvalidPerlOp,
+		"ErrInvalidRepeatOp":       syntax.ErrInvalidRepeatOp,
+		"ErrInvalidRepeatSize":     syntax.ErrInvalidRepeatSize,
+		"ErrInvalidUTF8":           syntax.ErrInvalidUTF8,
+		"ErrMissingBracket":        syntax.ErrMissingBracket,
+		"ErrMissingParen":          syntax.ErrMissingParen,
+		"ErrMissingRepeatArgument": syntax.ErrMissingRepeatArgument,
+		"ErrTrailingBackslash":     syntax.ErrTrailingBackslash,
+		"ErrUnexpectedParen":       syntax.ErrUnexpectedParen,
+		"Error":                    pry.Type((*syntax.Error)(nil)).Elem(),
+		"ErrorCode":                pry.Type((*syntax.ErrorCode)(nil)).Elem(),
+		"Flags":                    pry.Type((*syntax.Flags)(nil)).Elem(),
+		"FoldCase":                 syntax.FoldCase,
+		"Inst":                     pry.Type((*syntax.Inst)(nil)).Elem(),
+		"InstAlt":                  syntax.InstAlt,
+		"InstAltMatch":             syntax.InstAltMatch,
+		"InstCapture":              syntax.InstCapture,
+		"InstEmptyWidth":           syntax.InstEmptyWidth,
+		"InstFail":                 syntax.InstFail,
+		"InstMatch":                syntax.InstMatch,
+		"InstNop":                  syntax.InstNop,
+		"InstOp":                   pry.Type((*syntax.InstOp)(nil)).Elem(),
+		"InstRune":                 syntax.InstRune,
+		"InstRune1":                syntax.InstRune1,
+		"InstRuneAny":              syntax.InstRuneAny,
+		"InstRuneAnyNotNL":         syntax.InstRuneAnyNotNL,
+		"IsWordChar":               syntax.IsWordChar,
+		"Literal":                  syntax.Literal,
+		"MatchNL":                  syntax.MatchNL,
+		"NonGreedy":                syntax.NonGreedy,
+		"OneLine":                  syntax.OneLine,
+		"Op":                       pry.Type((*syntax.Op)(nil)).Elem(),
+		"OpAlternate":              syntax.OpAlternate,
+		"OpAnyChar":                syntax.OpAnyChar,
+		"OpAnyCharNotNL":           syntax.OpAnyCharNotNL,
+		"OpBeginLine":              syntax.OpBeginLine,
+		"OpBeginText":              syntax.OpBeginText,
+		"OpCapture":                syntax.OpCapture,
+		"OpCharClass":              syntax.OpCharClass,
+		"OpConcat":                 syntax.OpConcat,
+		"OpEmptyMatch":             syntax.OpEmptyMatch,
+		"OpEndLine":                syntax.OpEndLine,
+		"OpEndText":                syntax.OpEndText,
+		"OpLiteral":                syntax.OpLiteral,
+		"OpNoMatch":                syntax.OpNoMatch,
+		"OpNoWordBoundary":         syntax.OpNoWordBoundary,
+		"OpPlus":                   syntax.OpPlus,
+		"OpQuest":                  syntax.OpQuest,
+		"OpRepeat":                 syntax.OpRepeat,
+		"OpStar":                   syntax.OpStar,
+		"OpWordBoundary":           syntax.OpWordBoundary,
+		"POSIX":                    syntax.POSIX,
+		"Parse":                    syntax.Parse,
+		"Perl":                     syntax.Perl,
+		"PerlX":                    syntax.PerlX,
+		"Prog":                     pry.Type((*syntax.Prog)(nil)).Elem(),
+		"Regexp":                   pry.Type((*syntax.Regexp)(nil)).Elem(),
+		"Simple":                   syntax.Simple,
+		"UnicodeGroups":            syntax.UnicodeGroups,
+		"WasDollar":                syntax.WasDollar,
+	})
+}
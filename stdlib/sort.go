@@ -0,0 +1,34 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"sort"
+)
+
+func init() {
+	pry.RegisterPackage("sort", "sort", map[string]interface{}{
+		"Float64Slice":      pry.Type((*sort.Float64Slice)(nil)).Elem(),
+		"Float64s":          sort.Float64s,
+		"Float64sAreSorted": sort.Float64sAreSorted,
+		"IntSlice":          pry.Type((*sort.IntSlice)(nil)).Elem(),
+		"Interface":         pry.Type((*sort.Interface)(nil)).Elem(),
+		"Ints":              sort.Ints,
+		"IntsAreSorted":     sort.IntsAreSorted,
+		"IsSorted":          sort.IsSorted,
+		"Reverse":           sort.Reverse,
+		"Search":            sort.Search,
+		"SearchFloat64s":    sort.SearchFloat64s,
+		"SearchInts":        sort.SearchInts,
+		"SearchStrings":     sort.SearchStrings,
+		"Slice":             sort.Slice,
+		"SliceIsSorted":     sort.SliceIsSorted,
+		"SliceStable":       sort.SliceStable,
+		"Sort":              sort.Sort,
+		"Stable":            sort.Stable,
+		"StringSlice":       pry.Type((*sort.StringSlice)(nil)).Elem(),
+		"Strings":           sort.Strings,
+		"StringsAreSorted":  sort.StringsAreSorted,
+	})
+}
@@ -0,0 +1,9 @@
+// Package stdlib registers symbol tables of common standard library packages
+// with pry.RegisterPackage, so they can be used by the interpreter without
+// generating and compiling a program. Importing it for its side effects is
+// enough:
+//
+//	import _ "github.com/d4l3k/go-pry/stdlib"
+package stdlib
+
+//go:generate go run ./genstdlib ../playground/packages.txt os os/exec flag
@@ -0,0 +1,29 @@
+package stdlib
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/d4l3k/go-pry/pry"
+)
+
+func TestRegistered(t *testing.T) {
+	t.Parallel()
+
+	in := pry.NewInterpreter(pry.Options{AllowedPackages: []string{"strings", "math", "net/http", "bytes"}})
+	for src, expected := range map[string]interface{}{
+		`strings.ToUpper("a")`: "A",
+		`math.MaxUint64`:       uint64(18446744073709551615),
+		`http.DefaultClient`:   http.DefaultClient,
+		`b := bytes.Buffer{}; b.WriteString("x"); b.Len()`: 1,
+	} {
+		res := in.Eval(context.Background(), src)
+		if res.Err != nil {
+			t.Errorf("%s: %+v", src, res.Err)
+		} else if !reflect.DeepEqual(expected, res.Value) {
+			t.Errorf("%s: Expected %#v got %#v.", src, expected, res.Value)
+		}
+	}
+}
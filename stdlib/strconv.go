@@ -0,0 +1,50 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"strconv"
+)
+
+func init() {
+	pry.RegisterPackage("strconv", "strconv", map[string]interface{}{
+		"AppendBool":               strconv.AppendBool,
+		"AppendFloat":              strconv.AppendFloat,
+		"AppendInt":                strconv.AppendInt,
+		"AppendQuote":              strconv.AppendQuote,
+		"AppendQuoteRune":          strconv.AppendQuoteRune,
+		"AppendQuoteRuneToASCII":   strconv.AppendQuoteRuneToASCII,
+		"AppendQuoteRuneToGraphic": strconv.AppendQuoteRuneToGraphic,
+		"AppendQuoteToASCII":       strconv.AppendQuoteToASCII,
+		"AppendQuoteToGraphic":     strconv.AppendQuoteToGraphic,
+		"AppendUint":               strconv.AppendUint,
+		"Atoi":                     strconv.Atoi,
+		"CanBackquote":             strconv.CanBackquote,
+		"ErrRange":                 &strconv.ErrRange,
+		"ErrSyntax":                &strconv.ErrSyntax,
+		"FormatBool":               strconv.FormatBool,
+		"FormatComplex":            strconv.FormatComplex,
+		"FormatFloat":              strconv.FormatFloat,
+		"FormatInt":                strconv.FormatInt,
+		"FormatUint":               strconv.FormatUint,
+		"IntSize":                  strconv.IntSize,
+		"IsGraphic":                strconv.IsGraphic,
+		"IsPrint":                  strconv.IsPrint,
+		"Itoa":                     strconv.Itoa,
+		"NumError":                 pry.Type((*strconv.NumError)(nil)).Elem(),
+		"ParseBool":                strconv.ParseBool,
+		"ParseComplex":             strconv.ParseComplex,
+		"ParseFloat":               strconv.ParseFloat,
+		"ParseInt":                 strconv.ParseInt,
+		"ParseUint":                strconv.ParseUint,
+		"Quote":                    strconv.Quote,
+		"QuoteRune":                strconv.QuoteRune,
+		"QuoteRuneToASCII":         strconv.QuoteRuneToASCII,
+		"QuoteRuneToGraphic":       strconv.QuoteRuneToGraphic,
+		"QuoteToASCII":             strconv.QuoteToASCII,
+		"QuoteToGraphic":           strconv.QuoteToGraphic,
+		"Unquote":                  strconv.Unquote,
+		"UnquoteChar":              strconv.UnquoteChar,
+	})
+}
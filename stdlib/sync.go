@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"sync"
+)
+
+func init() {
+	pry.RegisterPackage("sync", "sync", map[string]interface{}{
+		"Cond":      pry.Type((*sync.Cond)(nil)).Elem(),
+		"Locker":    pry.Type((*sync.Locker)(nil)).Elem(),
+		"Map":       pry.Type((*sync.Map)(nil)).Elem(),
+		"Mutex":     pry.Type((*sync.Mutex)(nil)).Elem(),
+		"NewCond":   sync.NewCond,
+		"Once":      pry.Type((*sync.Once)(nil)).Elem(),
+		"Pool":      pry.Type((*sync.Pool)(nil)).Elem(),
+		"RWMutex":   pry.Type((*sync.RWMutex)(nil)).Elem(),
+		"WaitGroup": pry.Type((*sync.WaitGroup)(nil)).Elem(),
+	})
+}
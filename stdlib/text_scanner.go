@@ -0,0 +1,34 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"text/scanner"
+)
+
+func init() {
+	pry.RegisterPackage("text/scanner", "scanner", map[string]interface{}{
+		"Char":           scanner.Char,
+		"Comment":        scanner.Comment,
+		"EOF":            scanner.EOF,
+		"Float":          scanner.Float,
+		"GoTokens":       scanner.GoTokens,
+		"GoWhitespace":   int64(scanner.GoWhitespace),
+		"Ident":          scanner.Ident,
+		"Int":            scanner.Int,
+		"Position":       pry.Type((*scanner.Position)(nil)).Elem(),
+		"RawString":      scanner.RawString,
+		"ScanChars":      scanner.ScanChars,
+		"ScanComments":   scanner.ScanComments,
+		"ScanFloats":     scanner.ScanFloats,
+		"ScanIdents":     scanner.ScanIdents,
+		"ScanInts":       scanner.ScanInts,
+		"ScanRawStrings": scanner.ScanRawStrings,
+		"ScanStrings":    scanner.ScanStrings,
+		"Scanner":        pry.Type((*scanner.Scanner)(nil)).Elem(),
+		"SkipComments":   scanner.SkipComments,
+		"String":         scanner.String,
+		"TokenString":    scanner.TokenString,
+	})
+}
@@ -0,0 +1,22 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"text/tabwriter"
+)
+
+func init() {
+	pry.RegisterPackage("text/tabwriter", "tabwriter", map[string]interface{}{
+		"AlignRight":          tabwriter.AlignRight,
+		"Debug":               tabwriter.Debug,
+		"DiscardEmptyColumns": tabwriter.DiscardEmptyColumns,
+		"Escape":              tabwriter.Escape,
+		"FilterHTML":          tabwriter.FilterHTML,
+		"NewWriter":           tabwriter.NewWriter,
+		"StripEscape":         tabwriter.StripEscape,
+		"TabIndent":           tabwriter.TabIndent,
+		"Writer":              pry.Type((*tabwriter.Writer)(nil)).Elem(),
+	})
+}
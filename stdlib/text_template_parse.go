@@ -0,0 +1,62 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"text/template/parse"
+)
+
+func init() {
+	pry.RegisterPackage("text/template/parse", "parse", map[string]interface{}{
+		"ActionNode":     pry.Type((*parse.ActionNode)(nil)).Elem(),
+		"BoolNode":       pry.Type((*parse.BoolNode)(nil)).Elem(),
+		"BranchNode":     pry.Type((*parse.BranchNode)(nil)).Elem(),
+		"ChainNode":      pry.Type((*parse.ChainNode)(nil)).Elem(),
+		"CommandNode":    pry.Type((*parse.CommandNode)(nil)).Elem(),
+		"CommentNode":    pry.Type((*parse.CommentNode)(nil)).Elem(),
+		"DotNode":        pry.Type((*parse.DotNode)(nil)).Elem(),
+		"FieldNode":      pry.Type((*parse.FieldNode)(nil)).Elem(),
+		"IdentifierNode": pry.Type((*parse.IdentifierNode)(nil)).Elem(),
+		"IfNode":         pry.Type((*parse.IfNode)(nil)).Elem(),
+		"IsEmptyTree":    parse.IsEmptyTree,
+		"ListNode":       pry.Type((*parse.ListNode)(nil)).Elem(),
+		"Mode":           pry.Type((*parse.Mode)(nil)).Elem(),
+		"New":            parse.New,
+		"NewIdentifier":  parse.NewIdentifier,
+		"NilNode":        pry.Type((*parse.NilNode)(nil)).Elem(),
+		"Node":           pry.Type((*parse.Node)(nil)).Elem(),
+		"NodeAction":     parse.NodeAction,
+		"NodeBool":       parse.NodeBool,
+		"NodeChain":      parse.NodeChain,
+		"NodeCommand":    parse.NodeCommand,
+		"NodeComment":    parse.NodeComment,
+		"NodeDot":        parse.NodeDot,
+		"NodeField":      parse.NodeField,
+		"NodeIdentifier": parse.NodeIdentifier,
+		"NodeIf":         parse.NodeIf,
+		"NodeList":       parse.NodeList,
+		"NodeNil":        parse.NodeNil,
+		"NodeNumber":     parse.NodeNumber,
+		"NodePipe":       parse.NodePipe,
+		"NodeRange":      parse.NodeRange,
+		"NodeString":     parse.NodeString,
+		"NodeTemplate":   parse.NodeTemplate,
+		"NodeText":       parse.NodeText,
+		"NodeType":       pry.Type((*parse.NodeType)(nil)).Elem(),
+		"NodeVariable":   parse.NodeVariable,
+		"NodeWith":       parse.NodeWith,
+		"NumberNode":     pry.Type((*parse.NumberNode)(nil)).Elem(),
+		"Parse":          parse.Parse,
+		"ParseComments":  parse.ParseComments,
+		"PipeNode":       pry.Type((*parse.PipeNode)(nil)).Elem(),
+		"Pos":            pry.Type((*parse.Pos)(nil)).Elem(),
+		"RangeNode":      pry.Type((*parse.RangeNode)(nil)).Elem(),
+		"StringNode":     pry.Type((*parse.StringNode)(nil)).Elem(),
+		"TemplateNode":   pry.Type((*parse.TemplateNode)(nil)).Elem(),
+		"TextNode":       pry.Type((*parse.TextNode)(nil)).Elem(),
+		"Tree":           pry.Type((*parse.Tree)(nil)).Elem(),
+		"VariableNode":   pry.Type((*parse.VariableNode)(nil)).Elem(),
+		"WithNode":       pry.Type((*parse.WithNode)(nil)).Elem(),
+	})
+}
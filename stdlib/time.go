@@ -0,0 +1,80 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"time"
+)
+
+func init() {
+	pry.RegisterPackage("time", "time", map[string]interface{}{
+		"ANSIC":                  time.ANSIC,
+		"After":                  time.After,
+		"AfterFunc":              time.AfterFunc,
+		"April":                  time.April,
+		"August":                 time.August,
+		"Date":                   time.Date,
+		"December":               time.December,
+		"Duration":               pry.Type((*time.Duration)(nil)).Elem(),
+		"February":               time.February,
+		"FixedZone":              time.FixedZone,
+		"Friday":                 time.Friday,
+		"Hour":                   time.Hour,
+		"January":                time.January,
+		"July":                   time.July,
+		"June":                   time.June,
+		"Kitchen":                time.Kitchen,
+		"LoadLocation":           time.LoadLocation,
+		"LoadLocationFromTZData": time.LoadLocationFromTZData,
+		"Local":                  &time.Local,
+		"Location":               pry.Type((*time.Location)(nil)).Elem(),
+		"March":                  time.March,
+		"May":                    time.May,
+		"Microsecond":            time.Microsecond,
+		"Millisecond":            time.Millisecond,
+		"Minute":                 time.Minute,
+		"Monday":                 time.Monday,
+		"Month":                  pry.Type((*time.Month)(nil)).Elem(),
+		"Nanosecond":             time.Nanosecond,
+		"NewTicker":              time.NewTicker,
+		"NewTimer":               time.NewTimer,
+		"November":               time.November,
+		"Now":                    time.Now,
+		"October":                time.October,
+		"Parse":                  time.Parse,
+		"ParseDuration":          time.ParseDuration,
+		"ParseError":             pry.Type((*time.ParseError)(nil)).Elem(),
+		"ParseInLocation":        time.ParseInLocation,
+		"RFC1123":                time.RFC1123,
+		"RFC1123Z":               time.RFC1123Z,
+		"RFC3339":                time.RFC3339,
+		"RFC3339Nano":            time.RFC3339Nano,
+		"RFC822":                 time.RFC822,
+		"RFC822Z":                time.RFC822Z,
+		"RFC850":                 time.RFC850,
+		"RubyDate":               time.RubyDate,
+		"Saturday":               time.Saturday,
+		"Second":                 time.Second,
+		"September":              time.September,
+		"Since":                  time.Since,
+		"Sleep":                  time.Sleep,
+		"Stamp":                  time.Stamp,
+		"StampMicro":             time.StampMicro,
+		"StampMilli":             time.StampMilli,
+		"StampNano":              time.StampNano,
+		"Sunday":                 time.Sunday,
+		"Thursday":               time.Thursday,
+		"Tick":                   time.Tick,
+		"Ticker":                 pry.Type((*time.Ticker)(nil)).Elem(),
+		"Time":                   pry.Type((*time.Time)(nil)).Elem(),
+		"Timer":                  pry.Type((*time.Timer)(nil)).Elem(),
+		"Tuesday":                time.Tuesday,
+		"UTC":                    &time.UTC,
+		"Unix":                   time.Unix,
+		"UnixDate":               time.UnixDate,
+		"Until":                  time.Until,
+		"Wednesday":              time.Wednesday,
+		"Weekday":                pry.Type((*time.Weekday)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,18 @@
+// Code generated by go-pry extract. DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/d4l3k/go-pry/pry"
+	"unicode/utf16"
+)
+
+func init() {
+	pry.RegisterPackage("unicode/utf16", "utf16", map[string]interface{}{
+		"Decode":      utf16.Decode,
+		"DecodeRune":  utf16.DecodeRune,
+		"Encode":      utf16.Encode,
+		"EncodeRune":  utf16.EncodeRune,
+		"IsSurrogate": utf16.IsSurrogate,
+	})
+}